@@ -2,11 +2,15 @@ package hue
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"crypto/tls"
+	"encoding/base64"
 	"errors"
 	"fmt"
+	"html"
 	"io"
-	"math/rand"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -22,36 +26,105 @@ const (
 	ApiURL   = "https://api.meethue.com/bridge/"
 )
 
-type Authenticator struct {
-	config  *oauth2.Config
-	token   *oauth2.Token
-	context context.Context
+// defaultAuthTimeout is how long Authenticate waits for the user to log in
+const defaultAuthTimeout = time.Minute
+
+// ErrAuthTimeout is returned when the user didn't log in before the timeout of the Authenticator
+var ErrAuthTimeout = errors.New("hue: could not authenticate on time")
+
+// AuthenticatorOptions configures the remote API login, create your app at https://developers.meethue.com/my-apps/
+type AuthenticatorOptions struct {
+	AppID        string
+	ClientID     string
+	ClientSecret string
+
+	// RedirectURL is the callback URL of your app, e.g. http://localhost:8181/callback.
+	// The callback server listens on its port, a random port is used if it has none or 0.
+	RedirectURL string
+	// Timeout is how long Authenticate waits for the login, defaults to one minute
+	Timeout time.Duration
+	// PKCE adds a S256 code challenge to the login, so an intercepted code can't be exchanged
+	PKCE bool
+	// OpenURL shows the login page to the user, defaults to printing it and opening the browser
+	OpenURL func(authURL string) error
+	// HttpClient is used for the token requests
+	HttpClient *http.Client
 }
 
-func init() {
-	rand.Seed(time.Now().UnixNano())
+type Authenticator struct {
+	config     *oauth2.Config
+	token      *oauth2.Token
+	context    context.Context
+	store      CredentialStore
+	httpClient *http.Client
+	bridgeID   string
+
+	appID   string
+	timeout time.Duration
+	pkce    bool
+	openURL func(authURL string) error
 }
 
+// NewAuthenticator reads the app from the environment variables HUE_APP_ID, HUE_CLIENT_ID and HUE_CLIENT_SECRET.
+//
+// Deprecated: Use NewAuthenticatorWithOptions, it doesn't depend on the environment.
 func NewAuthenticator(redirectURL string) Authenticator {
-	appID := os.Getenv("HUE_APP_ID")
-	cfg := &oauth2.Config{
+	return *NewAuthenticatorWithOptions(&AuthenticatorOptions{
+		AppID:        os.Getenv("HUE_APP_ID"),
 		ClientID:     os.Getenv("HUE_CLIENT_ID"),
 		ClientSecret: os.Getenv("HUE_CLIENT_SECRET"),
 		RedirectURL:  redirectURL,
+	})
+}
+
+// NewAuthenticatorWithOptions returns an Authenticator for the app in opts
+func NewAuthenticatorWithOptions(opts *AuthenticatorOptions) *Authenticator {
+	if opts == nil {
+		opts = &AuthenticatorOptions{}
+	}
+
+	cfg := &oauth2.Config{
+		ClientID:     opts.ClientID,
+		ClientSecret: opts.ClientSecret,
+		RedirectURL:  opts.RedirectURL,
 		Scopes:       []string{},
 		Endpoint: oauth2.Endpoint{
-			AuthURL:  fmt.Sprintf("%s?appid=%s&deviceid=%s&devicename=browser", AuthURL, appID, appID),
+			AuthURL:  fmt.Sprintf("%s?appid=%s&deviceid=%s&devicename=browser", AuthURL, url.QueryEscape(opts.AppID), url.QueryEscape(opts.AppID)),
 			TokenURL: TokenURL,
 		},
 	}
 
-	tr := &http.Transport{
-		TLSNextProto: map[string]func(authority string, c *tls.Conn) http.RoundTripper{},
+	httpClient := opts.HttpClient
+	if httpClient == nil {
+		tr := &http.Transport{
+			TLSNextProto: map[string]func(authority string, c *tls.Conn) http.RoundTripper{},
+		}
+		httpClient = &http.Client{Transport: tr}
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+
+	timeout := opts.Timeout
+	if timeout <= 0 {
+		timeout = defaultAuthTimeout
+	}
+	openURL := opts.OpenURL
+	if openURL == nil {
+		openURL = func(authURL string) error {
+			fmt.Printf("Go to %v\n", authURL)
+			// The printed URL still works when there is no browser
+			browser.OpenURL(authURL)
+			return nil
+		}
 	}
-	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, &http.Client{Transport: tr})
-	return Authenticator{
-		config:  cfg,
-		context: ctx,
+
+	return &Authenticator{
+		config:     cfg,
+		context:    ctx,
+		httpClient: httpClient,
+		appID:      opts.AppID,
+		timeout:    timeout,
+		pkce:       opts.PKCE,
+		openURL:    openURL,
 	}
 }
 
@@ -60,6 +133,13 @@ func (a *Authenticator) SetAuthInfo(clientID, secretKey string) {
 	a.config.ClientSecret = secretKey
 }
 
+// SetCredentialStore keeps the token and the remote username of the bridge in store.
+// Authenticate reuses a stored token and refreshed tokens are saved.
+func (a *Authenticator) SetCredentialStore(store CredentialStore, bridgeID string) {
+	a.store = store
+	a.bridgeID = bridgeID
+}
+
 func (a *Authenticator) AuthURL(state string) string {
 	return a.config.AuthCodeURL(state)
 }
@@ -72,8 +152,20 @@ func (a *Authenticator) GetToken() *oauth2.Token {
 	return a.token
 }
 
+// Token exchanges the code of the callback request r, the state must be the one of the login URL
 func (a *Authenticator) Token(state string, r *http.Request) (*oauth2.Token, error) {
+	return a.exchangeCallback(a.config, state, r)
+}
+
+// errStateMismatch is returned for callback requests which weren't redirected from the login page
+var errStateMismatch = errors.New("hue: redirect state parameter doesn't match")
+
+func (a *Authenticator) exchangeCallback(cfg *oauth2.Config, state string, r *http.Request, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
 	values := r.URL.Query()
+	// Checked first, so only the login page can report an error
+	if values.Get("state") != state {
+		return nil, errStateMismatch
+	}
 	if e := values.Get("error"); e != "" {
 		return nil, errors.New("hue: auth failed - " + e)
 	}
@@ -81,152 +173,176 @@ func (a *Authenticator) Token(state string, r *http.Request) (*oauth2.Token, err
 	if code == "" {
 		return nil, errors.New("hue: didn't get access code")
 	}
-	actualState := values.Get("state")
-	if actualState != state {
-		return nil, errors.New("hue: redirect state parameter doesn't match")
+
+	token, err := cfg.Exchange(a.context, code, opts...)
+	if err != nil {
+		return nil, err
 	}
-	return a.config.Exchange(a.context, code)
+
+	return fixTokenType(token), nil
 }
 
 func (a *Authenticator) Exchange(code string, opts ...oauth2.AuthCodeOption) (*oauth2.Token, error) {
 	return a.config.Exchange(a.context, code, opts...)
 }
 
+// Authenticate logs in with the remote API, see AuthenticateContext
 func (a *Authenticator) Authenticate() (*Client, error) {
-	state := fmt.Sprintf("%v", rand.Intn(10000))
-	authUrl := a.AuthURL(state)
-	fmt.Printf("Go to %v\n", authUrl)
-	fmt.Println("Waiting 1 minute for the action")
+	return a.AuthenticateContext(context.Background())
+}
+
+// AuthenticateContext opens the login page and waits for the redirect to the callback URL.
+// Requests to the callback URL without the state of the login page are answered with 400 and ignored.
+// It returns ErrAuthTimeout if the user doesn't log in before the timeout.
+// A token in the credential store is used without login.
+func (a *Authenticator) AuthenticateContext(ctx context.Context) (*Client, error) {
+	if a.store != nil {
+		creds, err := a.store.Load(a.bridgeID)
+		if err == nil && creds.Token != nil {
+			a.token = creds.Token
+			return a.NewClient(creds.Token), nil
+		}
+		if err != nil && !errors.Is(err, ErrCredentialsNotFound) {
+			return nil, err
+		}
+	}
+
+	redirectURL, err := url.Parse(a.config.RedirectURL)
+	if err != nil {
+		return nil, err
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(redirectURL.Hostname(), redirectURL.Port()))
+	if err != nil {
+		return nil, err
+	}
+	// The listener picked the port if there was none
+	if redirectURL.Port() == "" || redirectURL.Port() == "0" {
+		redirectURL.Host = listener.Addr().String()
+	}
 
-	browser.OpenURL(authUrl)
+	cfg := *a.config
+	cfg.RedirectURL = redirectURL.String()
 
-	u, _ := url.Parse(a.config.RedirectURL)
-	srv := &http.Server{
-		Addr: fmt.Sprintf(":%s", u.Port()),
+	state, err := randomString(16)
+	if err != nil {
+		listener.Close()
+		return nil, err
 	}
 
-	tokenCh := make(chan *oauth2.Token)
+	var authOpts, exchangeOpts []oauth2.AuthCodeOption
+	if a.pkce {
+		verifier, err := randomString(32)
+		if err != nil {
+			listener.Close()
+			return nil, err
+		}
+		challenge := sha256.Sum256([]byte(verifier))
+		authOpts = append(authOpts,
+			oauth2.SetAuthURLParam("code_challenge", base64.RawURLEncoding.EncodeToString(challenge[:])),
+			oauth2.SetAuthURLParam("code_challenge_method", "S256"))
+		exchangeOpts = append(exchangeOpts, oauth2.SetAuthURLParam("code_verifier", verifier))
+	}
 
-	redirectUrl, _ := url.Parse(a.config.RedirectURL)
-	http.HandleFunc(redirectUrl.Path, func(rw http.ResponseWriter, r *http.Request) {
-		token, _ := a.Token(state, r)
+	type result struct {
+		token *oauth2.Token
+		err   error
+	}
+	// Buffered, so the handler never blocks after Authenticate returned
+	resultCh := make(chan result, 1)
 
-		io.WriteString(rw, `
+	mux := http.NewServeMux()
+	path := redirectURL.Path
+	if path == "" {
+		path = "/"
+	}
+	mux.HandleFunc(path, func(rw http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("state") != state {
+			// e.g. a prefetch of the browser or a forged request, keep waiting for the login
+			http.Error(rw, errStateMismatch.Error(), http.StatusBadRequest)
+			return
+		}
+
+		token, err := a.exchangeCallback(&cfg, state, r, exchangeOpts...)
+		if err != nil {
+			rw.WriteHeader(http.StatusBadRequest)
+			io.WriteString(rw, `
+		<html>
+			<body>
+				<h1>Login failed!</h1>
+				<h2>`+html.EscapeString(err.Error())+`</h2>
+			</body>
+		</html>`)
+		} else {
+			io.WriteString(rw, `
 		<html>
 			<body>
 				<h1>Login successful!</h1>
 				<h2>You can close this window.</h2>
 			</body>
 		</html>`)
+		}
 
-		go srv.Close()
-
-		// Hue response includes {"token_type": "BearerToken"},
-		// But we need "Bearer" when you call back.
-		token.TokenType = "Bearer"
-		tokenCh <- token
+		select {
+		case resultCh <- result{token: token, err: err}:
+		default:
+		}
 	})
 
-	go srv.ListenAndServe()
-
-	var client *Client
-	var token *oauth2.Token
-
-	select {
-	case token = <-tokenCh:
-		a.token = token
-		client = a.NewClient(token)
-		return client, nil
-
-	case <-time.After(1 * time.Minute):
-		fmt.Println("could not authenticate on time")
-		go srv.Close()
-		return nil, errors.New("could not authenticate on time")
-	}
-}
-
-func (c *Client) Login(username string) error {
-	c.clientId = username
-	return nil
-}
-
-func (c *Client) CreateRemoteUser() (string, error) {
-	c.logger.Info("Press the link button on the bridge")
-	c.logger.Info("Waiting for 30 seconds...")
-
-	time.Sleep(30 * time.Second)
+	srv := &http.Server{Handler: mux}
+	go srv.Serve(listener)
+	defer srv.Close()
 
-	c.logger.Info("Enable link button...")
-	err := c.EnableLinkButton()
-	if err != nil {
-		c.logger.Error(err, "could not enable link button")
-		return "", nil
+	if err := a.openURL(cfg.AuthCodeURL(state, authOpts...)); err != nil {
+		return nil, err
 	}
 
-	c.logger.Info("Add whitelist identifies...")
-	username, err := c.AddWhitelistIdentifier()
-	if err != nil {
-		c.logger.Error(err, "could not add whitelist identifier")
-		return "", nil
-	}
-
-	c.clientId = username
-
-	return username, nil
-}
+	timer := time.NewTimer(a.timeout)
+	defer timer.Stop()
 
-func (c *Client) EnableLinkButton() error {
-	var payload = struct {
-		LinkButton bool `json:"linkedbutton"`
-	}{LinkButton: true}
-	req, err := c.newRequest(http.MethodPut, "0/config", payload)
-	if err != nil {
-		return err
-	}
+	select {
+	case res := <-resultCh:
+		if res.err != nil {
+			return nil, res.err
+		}
+		a.token = res.token
+		if a.store != nil {
+			err := updateCredentials(a.store, a.bridgeID, func(creds *Credentials) { creds.Token = res.token })
+			if err != nil {
+				return nil, err
+			}
+		}
+		return a.NewClient(res.token), nil
 
-	resp, err := c.do(context.Background(), req, nil)
-	if err != nil {
-		return err
-	}
+	case <-ctx.Done():
+		return nil, ctx.Err()
 
-	if resp.StatusCode == http.StatusOK {
-		return nil
+	case <-timer.C:
+		return nil, ErrAuthTimeout
 	}
-
-	return errors.New("server returned failed status")
 }
 
-func (c *Client) AddWhitelistIdentifier() (string, error) {
-	var payload = struct {
-		DeviceType string `json:"devicetype"`
-	}{DeviceType: os.Getenv("HUE_APP_ID")}
-	req, err := c.newRequest(http.MethodPost, "", payload)
-	if err != nil {
+// randomString returns n random bytes encoded for URLs
+func randomString(n int) (string, error) {
+	b := make([]byte, n)
+	if _, err := rand.Read(b); err != nil {
 		return "", err
 	}
-
-	apiResponses := new([]ApiResponse)
-	resp, err := c.do(context.Background(), req, apiResponses)
-	if err != nil {
-		return "", err
-	}
-
-	if resp.StatusCode == http.StatusOK {
-		if (*apiResponses)[0].Success != nil {
-			return (*apiResponses)[0].Success["username"].(string), nil
-		} else {
-			return "", errors.New((*apiResponses)[0].Error.Description)
-		}
-	}
-
-	return "", errors.New("server returned failed status")
+	return base64.RawURLEncoding.EncodeToString(b), nil
 }
 
+// NewClient returns a remote client which refreshes token when it expires.
+// The username of the credential store is used, if there is one.
 func (a *Authenticator) NewClient(token *oauth2.Token) *Client {
-	httpClient := a.config.Client(a.context, token)
-	client, err := newClient(ApiURL, &ClientOptions{HttpClient: httpClient})
-	if err != nil {
-		return nil
+	var src oauth2.TokenSource = a.config.TokenSource(a.context, token)
+	src = bearerTokenSource{src: src}
+	if a.store != nil {
+		src = &storeTokenSource{src: src, store: a.store, bridgeID: a.bridgeID, last: token.AccessToken}
+	}
+
+	opts := &ClientOptions{HttpClient: a.httpClient, CredentialStore: a.store, BridgeID: a.bridgeID}
+	client := NewRemoteClient(src, "", opts)
+	if client != nil {
+		client.deviceType = a.appID
 	}
 	return client
 }
@@ -0,0 +1,151 @@
+package hue
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setupAuth returns an Authenticator whose token endpoint is a test server.
+// The login page is "opened" by calling redirect with the parsed login URL.
+func setupAuth(t *testing.T, opts *AuthenticatorOptions, redirect func(login *url.URL)) (*Authenticator, func()) {
+	challenges := make(map[string]string)
+	tokenServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		r.ParseForm()
+
+		code := r.PostForm.Get("code")
+		if challenge, ok := challenges[code]; ok {
+			sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+			if base64.RawURLEncoding.EncodeToString(sum[:]) != challenge {
+				http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token":"access","refresh_token":"refresh","token_type":"BearerToken","expires_in":604800}`)
+	}))
+
+	opts.AppID = "go-hue"
+	opts.ClientID = "client"
+	opts.ClientSecret = "secret"
+	opts.OpenURL = func(authURL string) error {
+		login, err := url.Parse(authURL)
+		if err != nil {
+			return err
+		}
+		// The code is exchanged with the verifier of this challenge
+		if challenge := login.Query().Get("code_challenge"); challenge != "" {
+			challenges["code"] = challenge
+		}
+		go redirect(login)
+		return nil
+	}
+
+	auth := NewAuthenticatorWithOptions(opts)
+	auth.config.Endpoint.TokenURL = tokenServer.URL
+
+	return auth, tokenServer.Close
+}
+
+// callback follows the redirect of the login page with the query and returns the status code
+func callback(t *testing.T, login *url.URL, query url.Values) int {
+	redirectURL, _ := url.Parse(login.Query().Get("redirect_uri"))
+	redirectURL.RawQuery = query.Encode()
+
+	resp, err := http.Get(redirectURL.String())
+	if err != nil {
+		t.Errorf("Callback failed: %v", err)
+		return 0
+	}
+	resp.Body.Close()
+	return resp.StatusCode
+}
+
+func TestAuthenticator_Authenticate(t *testing.T) {
+	var login *url.URL
+	auth, teardown := setupAuth(t, &AuthenticatorOptions{RedirectURL: "http://127.0.0.1/callback", PKCE: true}, func(u *url.URL) {
+		login = u
+		callback(t, u, url.Values{"code": {"code"}, "state": {u.Query().Get("state")}})
+	})
+	defer teardown()
+
+	client, err := auth.Authenticate()
+	if err != nil {
+		t.Fatalf("Authenticate returned error: %+v", err)
+	}
+	assert.NotNil(t, client)
+	assert.Equal(t, "access", auth.GetToken().AccessToken)
+	assert.Equal(t, "Bearer", auth.GetToken().TokenType)
+
+	assert.Equal(t, "go-hue", login.Query().Get("appid"))
+	assert.Equal(t, "S256", login.Query().Get("code_challenge_method"))
+	assert.True(t, len(login.Query().Get("state")) >= 16)
+
+	redirectURL, _ := url.Parse(login.Query().Get("redirect_uri"))
+	assert.NotEmpty(t, redirectURL.Port())
+	assert.Equal(t, "/callback", redirectURL.Path)
+
+	// The callback server isn't registered globally, so it can run again
+	_, err = auth.Authenticate()
+	assert.NoError(t, err)
+}
+
+func TestAuthenticator_Authenticate_StateMismatch(t *testing.T) {
+	statuses := make(chan []int, 1)
+	auth, teardown := setupAuth(t, &AuthenticatorOptions{RedirectURL: "http://127.0.0.1:0/callback"}, func(u *url.URL) {
+		// A favicon request, a forged callback and an error without state don't end the login
+		statuses <- []int{
+			callback(t, u, url.Values{}),
+			callback(t, u, url.Values{"code": {"code"}, "state": {"forged"}}),
+			callback(t, u, url.Values{"error": {"access_denied"}}),
+			callback(t, u, url.Values{"code": {"code"}, "state": {u.Query().Get("state")}}),
+		}
+	})
+	defer teardown()
+
+	client, err := auth.Authenticate()
+	if err != nil {
+		t.Fatalf("Authenticate returned error: %+v", err)
+	}
+	assert.NotNil(t, client)
+	assert.Equal(t, "access", auth.GetToken().AccessToken)
+	assert.Equal(t, []int{http.StatusBadRequest, http.StatusBadRequest, http.StatusBadRequest, http.StatusOK}, <-statuses)
+}
+
+func TestAuthenticator_Authenticate_OnlyStateMismatch(t *testing.T) {
+	auth, teardown := setupAuth(t, &AuthenticatorOptions{RedirectURL: "http://127.0.0.1/callback", Timeout: 100 * time.Millisecond}, func(u *url.URL) {
+		callback(t, u, url.Values{"code": {"code"}, "state": {"forged"}})
+	})
+	defer teardown()
+
+	_, err := auth.Authenticate()
+	assert.True(t, errors.Is(err, ErrAuthTimeout))
+}
+
+func TestAuthenticator_Authenticate_Denied(t *testing.T) {
+	auth, teardown := setupAuth(t, &AuthenticatorOptions{RedirectURL: "http://127.0.0.1/callback"}, func(u *url.URL) {
+		callback(t, u, url.Values{"error": {"access_denied"}, "state": {u.Query().Get("state")}})
+	})
+	defer teardown()
+
+	_, err := auth.Authenticate()
+	assert.EqualError(t, err, "hue: auth failed - access_denied")
+}
+
+func TestAuthenticator_Authenticate_Timeout(t *testing.T) {
+	auth, teardown := setupAuth(t, &AuthenticatorOptions{RedirectURL: "http://127.0.0.1/callback", Timeout: 10 * time.Millisecond}, func(u *url.URL) {})
+	defer teardown()
+
+	_, err := auth.Authenticate()
+	assert.True(t, errors.Is(err, ErrAuthTimeout))
+}
@@ -11,8 +11,8 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
-	"github.com/bombsimon/logrusr"
 	"github.com/go-logr/logr"
 	"github.com/sirupsen/logrus"
 )
@@ -25,9 +25,13 @@ const (
 
 type Response struct {
 	*http.Response
+
+	Retries int // Number of times the request was sent again, see RetryPolicy
 }
 
-// ApiResponse that Hue returns
+// ApiResponse that Hue returns, one for every attribute of a change.
+// The bridge applies the other attributes when some of them fail, so methods returning []ApiResponse
+// return the responses together with the error.
 type ApiResponse struct {
 	Success map[string]interface{} `json:"success,omitempty"`
 	Error   *ApiError              `json:"error,omitempty"`
@@ -40,26 +44,38 @@ type ApiError struct {
 	Description string `json:"description"`
 }
 
-type discoverResponse struct {
-	ID   string `json:"id"`
-	Host string `json:"internalipaddress"`
-}
-
 type createUserRequest struct {
-	DeviceType string `json:"devicetype"`
+	DeviceType        string `json:"devicetype"`
+	GenerateClientKey bool   `json:"generateclientkey,omitempty"`
 }
 
 type Client struct {
 	client  *http.Client
 	baseURL *url.URL
 
-	userAgent string
-	clientId  string // username for hue bridge
-	logger    logr.Logger
-	common    service
-
-	Lights *LightService
-	Groups *GroupService
+	userAgent  string
+	clientId   string // username for hue bridge
+	clientKey  string // PSK for entertainment streaming, only known for created users
+	store      CredentialStore
+	bridgeID   string
+	deviceType string // devicetype of the remote user, the app id
+	remote     bool   // whether baseURL is the remote API
+	limiter    *rateLimiter
+	retrier    *retrier
+	roundTrip  RoundTrip // http client wrapped by the middlewares
+	metrics    Metrics
+	logger     logr.Logger
+	common     service
+
+	Lights        *LightService
+	Groups        *GroupService
+	Sensors       *SensorService
+	Scenes        *SceneService
+	Schedules     *ScheduleService
+	Rules         *RuleService
+	Config        *ConfigService
+	ResourceLinks *ResourceLinkService
+	Entertainment *EntertainmentService
 }
 
 type service struct {
@@ -68,46 +84,32 @@ type service struct {
 
 type ClientOptions struct {
 	HttpClient *http.Client
-	LogLevel   logrus.Level
-}
 
-// Discover gets hue bridge host address
-func Discover() (string, error) {
-	req, err := http.NewRequest(http.MethodGet, discoveryUrl, nil)
-	if err != nil {
-		return "", err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
-
-	discoveryResponses := new([]discoverResponse)
-	resp, err := http.DefaultClient.Do(req)
-	if err != nil {
-		return "", err
-	}
-	defer resp.Body.Close()
+	// Logger receives the logs of the client, e.g. zapr.NewLogger(zapLogger) or NewSlogLogger(handler).
+	// Requests are logged at V(LogLevelRequest) and payloads at V(LogLevelResponse), the username is redacted.
+	// Nil logs to logrus with LogLevel, zero is logrus.InfoLevel.
+	Logger   logr.Logger
+	LogLevel logrus.Level
 
-	if resp.StatusCode != http.StatusOK {
-		return "", errors.New("invalid status code returned")
-	}
+	// RateLimit delays commands to lights and groups, nil sends them immediately
+	RateLimit *RateLimit
+	// RetryPolicy retries requests which failed transiently, nil sends them once
+	RetryPolicy *RetryPolicy
 
-	bytes, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return "", err
-	}
+	// CredentialStore keeps the username of the bridge with BridgeID.
+	// NewClient loads it when the clientId is empty, CreateUser and Pair save new users.
+	CredentialStore CredentialStore
+	BridgeID        string
 
-	err = json.Unmarshal(bytes, discoveryResponses)
-	if err != nil {
-		return "", err
-	}
+	// TLS connects to the local bridge with HTTPS, nil uses HTTP. It needs BridgeID,
+	// use NewClientWithOptions to get the error of invalid options.
+	TLS *TLSOptions
 
-	if discoveryResponses == nil || len(*discoveryResponses) == 0 {
-		return "", errors.New("no bridge found on your network")
-	}
+	// Middlewares wrap every request to the bridge, the first one is called first
+	Middlewares []Middleware
 
-	// Use the first bridge on the network
-	return (*discoveryResponses)[0].Host, nil
+	// Metrics records every request to the bridge labeled with BridgeID, nil records nothing
+	Metrics Metrics
 }
 
 func newClient(host string, opts *ClientOptions) (*Client, error) {
@@ -123,72 +125,92 @@ func newClient(host string, opts *ClientOptions) (*Client, error) {
 		return nil, err
 	}
 
-	c := &Client{client: httpClient, baseURL: u, userAgent: userAgent}
-	c.logger = logrusr.NewLogger(logrus.New())
+	c := &Client{client: httpClient, baseURL: u, userAgent: userAgent, metrics: noopMetrics{}}
+	if opts != nil {
+		c.store = opts.CredentialStore
+		c.bridgeID = opts.BridgeID
+		if opts.RateLimit != nil {
+			c.limiter = newRateLimiter(opts.RateLimit)
+		}
+		if opts.RetryPolicy != nil {
+			c.retrier = newRetrier(opts.RetryPolicy)
+		}
+		if opts.Metrics != nil {
+			c.metrics = opts.Metrics
+		}
+	}
+	c.logger = newLogger(opts)
 	c.common.client = c
 
+	c.roundTrip = func(req *Request) (*http.Response, error) {
+		return c.client.Do(req.Request)
+	}
+	if opts != nil {
+		c.roundTrip = chainMiddlewares(c.roundTrip, opts.Middlewares)
+	}
+
 	c.Lights = (*LightService)(&c.common)
 	c.Groups = (*GroupService)(&c.common)
+	c.Sensors = (*SensorService)(&c.common)
+	c.Scenes = (*SceneService)(&c.common)
+	c.Schedules = (*ScheduleService)(&c.common)
+	c.Rules = (*RuleService)(&c.common)
+	c.Config = (*ConfigService)(&c.common)
+	c.ResourceLinks = (*ResourceLinkService)(&c.common)
+	c.Entertainment = (*EntertainmentService)(&c.common)
 
 	return c, nil
 }
 
+// NewClient returns client of the local bridge at host.
+// It logs the error and returns nil if opts are invalid, e.g. TLS without BridgeID,
+// use NewClientWithOptions to get the error.
 func NewClient(host, clientId string, opts *ClientOptions) *Client {
-	c, err := newClient(fmt.Sprintf("http://%v/api/", host), opts)
+	c, err := NewClientWithOptions(host, clientId, opts)
 	if err != nil {
-		c.logger.Error(err, "Couldn't create client")
+		newLogger(opts).Error(err, "Couldn't create client")
 		return nil
 	}
-	c.clientId = clientId
 
 	return c
 }
 
-// CreateUser creates local user on the bridge and returns authenticated client instance
-// Don't forget to press bridge button otherwise it will fail
-func CreateUser(host, deviceType string, opts *ClientOptions) (*Client, error) {
-	buf := &bytes.Buffer{}
-	err := json.NewEncoder(buf).Encode(&createUserRequest{DeviceType: deviceType})
+// NewClientWithOptions returns client of the local bridge at host, or the error if opts are invalid.
+// If clientId is empty the username is loaded from the CredentialStore of opts.
+func NewClientWithOptions(host, clientId string, opts *ClientOptions) (*Client, error) {
+	c, err := newLocalClient(host, opts)
 	if err != nil {
 		return nil, err
 	}
-
-	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s/api", host), buf)
-	if err != nil {
-		return nil, err
+	c.clientId = clientId
+	if clientId == "" {
+		c.loadCredentials()
 	}
 
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Accept", "application/json")
+	return c, nil
+}
 
-	apiResponses := new([]ApiResponse)
-	resp, err := http.DefaultClient.Do(req)
+// CreateUser creates local user on the bridge and returns authenticated client instance
+// Don't forget to press bridge button otherwise it will fail, use Pair to wait for the button
+func CreateUser(host, deviceType string, opts *ClientOptions) (*Client, error) {
+	c, err := newLocalClient(host, opts)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("invalid status code returned")
-	}
 
-	bytes, err := ioutil.ReadAll(resp.Body)
+	creds, err := c.createUser(context.Background(), deviceType)
 	if err != nil {
 		return nil, err
 	}
 
-	err = json.Unmarshal(bytes, apiResponses)
-	if err != nil {
-		return nil, err
-	}
+	c.clientId = creds.Username
+	c.clientKey = creds.ClientKey
 
-	if apiResponses == nil || len(*apiResponses) == 0 || (*apiResponses)[0].Error != nil {
-		return nil, errors.New((*apiResponses)[0].Error.Description)
+	if err := c.saveCredentials(creds); err != nil {
+		return nil, err
 	}
 
-	clientId := (*apiResponses)[0].Success["username"].(string)
-
-	return NewClient(host, clientId, opts), nil
+	return c, nil
 }
 
 // GetHost returns ip address of hue bridge
@@ -201,6 +223,41 @@ func (c *Client) GetClientID() string {
 	return c.clientId
 }
 
+// GetClientKey returns the client key of a user created by CreateUser, save it for entertainment streaming
+func (c *Client) GetClientKey() string {
+	return c.clientKey
+}
+
+// loadCredentials uses the stored username and client key, if there is a store
+func (c *Client) loadCredentials() {
+	if c.store == nil || c.bridgeID == "" {
+		return
+	}
+
+	creds, err := c.store.Load(c.bridgeID)
+	if err != nil {
+		if !errors.Is(err, ErrCredentialsNotFound) {
+			c.logger.Error(err, "Couldn't load credentials", "bridge", c.bridgeID)
+		}
+		return
+	}
+
+	c.clientId = creds.Username
+	c.clientKey = creds.ClientKey
+}
+
+// saveCredentials stores the username and client key of a new user, if there is a store
+func (c *Client) saveCredentials(creds *Credentials) error {
+	if c.store == nil || c.bridgeID == "" {
+		return nil
+	}
+
+	return updateCredentials(c.store, c.bridgeID, func(stored *Credentials) {
+		stored.Username = creds.Username
+		stored.ClientKey = creds.ClientKey
+	})
+}
+
 func (c *Client) newRequest(method, url string, payload interface{}) (*http.Request, error) {
 	if !strings.HasSuffix(c.baseURL.Path, "/") {
 		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.baseURL)
@@ -236,43 +293,96 @@ func (c *Client) newRequest(method, url string, payload interface{}) (*http.Requ
 	return req, nil
 }
 
-func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (response *Response, err error) {
 	if ctx == nil {
 		return nil, errors.New("context must be non-nil")
 	}
 	req = req.WithContext(ctx)
+	start := time.Now()
+
+	send := func(req *http.Request) (*http.Response, error) {
+		// Every attempt is a command for the bridge
+		if c.limiter != nil {
+			if err := c.limiter.wait(ctx, req); err != nil {
+				return nil, err
+			}
+		}
+		return c.roundTrip(c.newMiddlewareRequest(req))
+	}
+
+	var resp *http.Response
+	var retries int
+	defer func() {
+		duration := time.Since(start)
+		c.logRequest(req, resp, retries, duration, err)
+		c.observeRequest(req, resp, retries, duration, err)
+	}()
 
-	resp, err := c.client.Do(req)
+	if c.retrier != nil {
+		resp, retries, err = c.retrier.do(ctx, req, send)
+	} else {
+		resp, err = send(req)
+	}
 	if err != nil {
-		return &Response{Response: resp}, err
+		return &Response{Response: resp, Retries: retries}, err
 	}
 	defer resp.Body.Close()
 
-	switch v := v.(type) {
-	case nil:
-	case io.Writer:
-		_, err = io.Copy(v, resp.Body)
-	default:
-		decErr := json.NewDecoder(resp.Body).Decode(v)
-		if decErr == io.EOF {
-			decErr = nil // ignore EOF errors caused by empty response body
-		}
-		if decErr != nil {
+	if w, ok := v.(io.Writer); ok {
+		_, err = io.Copy(w, resp.Body)
+		return &Response{Response: resp, Retries: retries}, err
+	}
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return &Response{Response: resp, Retries: retries}, err
+	}
+
+	// The bridge reports failures in the body, usually with 200 OK.
+	// They take precedence over decoding errors since a list of errors
+	// can't be decoded into the expected resource.
+	apiErr := apiErrors(body)
+	if apiErr == nil && resp.StatusCode >= http.StatusBadRequest {
+		// e.g. the remote API rejects an expired token before the bridge is reached
+		return &Response{Response: resp, Retries: retries}, &HTTPError{StatusCode: resp.StatusCode, Status: resp.Status, Body: string(body)}
+	}
+
+	if v != nil && len(bytes.TrimSpace(body)) > 0 {
+		if decErr := json.Unmarshal(body, v); decErr != nil {
 			err = decErr
 		}
 	}
-	return &Response{Response: resp}, err
+	if apiErr != nil {
+		err = apiErr
+	}
+
+	return &Response{Response: resp, Retries: retries}, err
+}
+
+// logRequest logs a request with its status, the duration includes retries and waiting for the rate limit
+func (c *Client) logRequest(req *http.Request, resp *http.Response, retries int, duration time.Duration, err error) {
+	logger := c.logger.V(LogLevelRequest)
+	if !logger.Enabled() {
+		return
+	}
+
+	kv := []interface{}{"method", req.Method, "path", c.redactPath(req.URL.Path), "duration", duration}
+	if resp != nil {
+		kv = append(kv, "status", resp.StatusCode)
+	}
+	if retries > 0 {
+		kv = append(kv, "retries", retries)
+	}
+	if err != nil {
+		kv = append(kv, "error", c.redactError(err).Error())
+	}
+	logger.Info("Request sent", kv...)
 }
 
 func (c *Client) path(service string, params ...string) string {
 	if c.clientId == "" {
 		c.logger.Info("clientId is missing")
 	}
-	if len(params) == 0 {
-		return fmt.Sprintf("%v/%v", c.clientId, service)
-	} else if len(params) == 1 {
-		return fmt.Sprintf("%v/%v/%v", c.clientId, service, params[0])
-	}
 
-	return fmt.Sprintf("%v/%v/%v/%v", c.clientId, service, params[0], params[1])
+	return strings.Join(append([]string{c.clientId, service}, params...), "/")
 }
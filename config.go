@@ -0,0 +1,58 @@
+package hue
+
+import (
+	"context"
+	"net/http"
+)
+
+// ConfigService has functions for the configuration of the bridge
+type ConfigService service
+
+const configServiceName = "config"
+
+func (s *ConfigService) configServicePath(params ...string) string {
+	return s.client.path(configServiceName, params...)
+}
+
+// Get returns the configuration of the bridge
+func (s *ConfigService) Get(ctx context.Context) (*BridgeConfig, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, s.configServicePath(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := new(BridgeConfig)
+	resp, err := s.client.do(ctx, req, config)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return config, resp, nil
+}
+
+// Update changes the writable attributes of the configuration
+func (s *ConfigService) Update(ctx context.Context, payload ConfigParams) ([]ApiResponse, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPut, s.configServicePath(), payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	return apiResponses, resp, err
+}
+
+// DeleteWhitelist removes the user from the whitelist, the username can't access the bridge anymore
+func (s *ConfigService) DeleteWhitelist(ctx context.Context, username string) (*Response, error) {
+	req, err := s.client.newRequest(http.MethodDelete, s.configServicePath("whitelist", username), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
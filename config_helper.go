@@ -0,0 +1,66 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoUpdateReady is returned by InstallUpdate when there is no downloaded update to install
+var ErrNoUpdateReady = errors.New("hue: no software update is ready to install")
+
+// swUpdatePollInterval is how often the bridge is asked whether the update check is done
+var swUpdatePollInterval = 2 * time.Second
+
+// CheckForUpdate asks the bridge to look for software updates and waits until the check is done.
+// It returns the software update state after the check, e.g. SWUpdateStateAnyReadyToInstall.
+func (s *ConfigService) CheckForUpdate(ctx context.Context) (*SWUpdate2, error) {
+	_, _, err := s.Update(ctx, ConfigParams{SWUpdate2: &SWUpdate2Params{CheckForUpdate: Bool(true)}})
+	if err != nil {
+		return nil, err
+	}
+
+	ticker := time.NewTicker(swUpdatePollInterval)
+	defer ticker.Stop()
+
+	for {
+		config, _, err := s.Get(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !config.SWUpdate2.CheckForUpdate {
+			return &config.SWUpdate2, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// InstallUpdate installs the software updates which are ready to install.
+// ErrNoUpdateReady is returned if nothing was downloaded yet, use CheckForUpdate first.
+func (s *ConfigService) InstallUpdate(ctx context.Context) error {
+	config, _, err := s.Get(ctx)
+	if err != nil {
+		return err
+	}
+
+	switch config.SWUpdate2.State {
+	case SWUpdateStateAnyReadyToInstall, SWUpdateStateAllReadyToInstall:
+	default:
+		return ErrNoUpdateReady
+	}
+
+	_, _, err = s.Update(ctx, ConfigParams{SWUpdate2: &SWUpdate2Params{Install: Bool(true)}})
+	return err
+}
+
+// SetAutoInstall turns automatic software updates on or off, updateTime is the time of day e.g. T14:00:00
+func (s *ConfigService) SetAutoInstall(ctx context.Context, on bool, updateTime string) error {
+	autoInstall := &SWAutoInstall{On: on, UpdateTime: updateTime}
+	_, _, err := s.Update(ctx, ConfigParams{SWUpdate2: &SWUpdate2Params{AutoInstall: autoInstall}})
+	return err
+}
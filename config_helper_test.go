@@ -0,0 +1,127 @@
+package hue
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigService_CheckForUpdate(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	defer func(d time.Duration) { swUpdatePollInterval = d }(swUpdatePollInterval)
+	swUpdatePollInterval = time.Millisecond
+
+	config, _ := ioutil.ReadFile("testdata/Config_Get.json")
+	checking := strings.Replace(string(config), `"checkforupdate": false`, `"checkforupdate": true`, 1)
+	update, _ := ioutil.ReadFile("testdata/Config_CheckForUpdate.json")
+
+	polls := 0
+	mux.HandleFunc("/username/config", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodPut:
+			var payload ConfigParams
+			getPayload(t, r, &payload)
+			assert.True(t, *payload.SWUpdate2.CheckForUpdate)
+			fmt.Fprint(w, string(update))
+		case http.MethodGet:
+			polls++
+			if polls < 3 {
+				fmt.Fprint(w, checking)
+				return
+			}
+			fmt.Fprint(w, string(config))
+		}
+	})
+
+	ctx := context.Background()
+	got, err := client.Config.CheckForUpdate(ctx)
+	if err != nil {
+		t.Errorf("Config.CheckForUpdate returned error: %+v", err)
+	}
+
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, SWUpdateStateAnyReadyToInstall, got.State)
+}
+
+func TestConfigService_CheckForUpdate_Canceled(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	defer func(d time.Duration) { swUpdatePollInterval = d }(swUpdatePollInterval)
+	swUpdatePollInterval = time.Millisecond
+
+	config, _ := ioutil.ReadFile("testdata/Config_Get.json")
+	checking := strings.Replace(string(config), `"checkforupdate": false`, `"checkforupdate": true`, 1)
+	update, _ := ioutil.ReadFile("testdata/Config_CheckForUpdate.json")
+
+	mux.HandleFunc("/username/config", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			fmt.Fprint(w, string(update))
+			return
+		}
+		fmt.Fprint(w, checking)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err := client.Config.CheckForUpdate(ctx)
+	assert.Error(t, err)
+}
+
+func TestConfigService_InstallUpdate(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	config, _ := ioutil.ReadFile("testdata/Config_Get.json")
+	install, _ := ioutil.ReadFile("testdata/Config_InstallUpdate.json")
+
+	installed := false
+	mux.HandleFunc("/username/config", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodPut {
+			var payload ConfigParams
+			getPayload(t, r, &payload)
+			assert.True(t, *payload.SWUpdate2.Install)
+			installed = true
+			fmt.Fprint(w, string(install))
+			return
+		}
+		fmt.Fprint(w, string(config))
+	})
+
+	ctx := context.Background()
+	err := client.Config.InstallUpdate(ctx)
+	if err != nil {
+		t.Errorf("Config.InstallUpdate returned error: %+v", err)
+	}
+
+	assert.True(t, installed)
+}
+
+func TestConfigService_InstallUpdate_NotReady(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	config, _ := ioutil.ReadFile("testdata/Config_Get.json")
+	noUpdates := strings.Replace(string(config), `"state": "anyreadytoinstall"`, `"state": "noupdates"`, 1)
+
+	mux.HandleFunc("/username/config", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, noUpdates)
+	})
+
+	ctx := context.Background()
+	err := client.Config.InstallUpdate(ctx)
+	assert.Equal(t, ErrNoUpdateReady, err)
+}
@@ -0,0 +1,101 @@
+package hue
+
+// Software update states of swupdate2
+const (
+	SWUpdateStateUnknown           = "unknown"
+	SWUpdateStateNoUpdates         = "noupdates"
+	SWUpdateStateTransferring      = "transferring"
+	SWUpdateStateAnyReadyToInstall = "anyreadytoinstall"
+	SWUpdateStateAllReadyToInstall = "allreadytoinstall"
+	SWUpdateStateInstalling        = "installing"
+)
+
+// BridgeConfig struct that represents the configuration of the bridge
+type BridgeConfig struct {
+	Name             string                    `json:"name"`             // Name of the bridge
+	ZigbeeChannel    int                       `json:"zigbeechannel"`    // 11, 15, 20 or 25
+	BridgeID         string                    `json:"bridgeid"`         // Unique id of the bridge
+	MAC              string                    `json:"mac"`              // MAC address of the bridge
+	DHCP             bool                      `json:"dhcp"`             // Whether the IP address is received from DHCP
+	IPAddress        string                    `json:"ipaddress"`        // IP address of the bridge
+	Netmask          string                    `json:"netmask"`          // Network mask of the bridge
+	Gateway          string                    `json:"gateway"`          // Gateway IP address of the bridge
+	ProxyAddress     string                    `json:"proxyaddress"`     // Proxy address, "none" if not set
+	ProxyPort        int                       `json:"proxyport"`        // Proxy port, 0 if not set
+	UTC              string                    `json:"UTC"`              // Current time of the bridge in UTC
+	LocalTime        string                    `json:"localtime"`        // Current time of the bridge in its timezone
+	Timezone         string                    `json:"timezone"`         // Timezone of the bridge, e.g. Europe/Amsterdam
+	ModelID          string                    `json:"modelid"`          // Model of the bridge, e.g. BSB002
+	DatastoreVersion string                    `json:"datastoreversion"` // Version of the datastore
+	SWVersion        string                    `json:"swversion"`        // Software version of the bridge
+	APIVersion       string                    `json:"apiversion"`       // Version of the API
+	SWUpdate2        SWUpdate2                 `json:"swupdate2"`        // Software update state of the bridge and devices
+	LinkButton       bool                      `json:"linkbutton"`       // Whether the link button was pressed in the last 30 seconds
+	PortalServices   bool                      `json:"portalservices"`   // Whether the bridge may connect to the portal
+	PortalConnection string                    `json:"portalconnection"` // connected or disconnected
+	PortalState      PortalState               `json:"portalstate"`      // Connection state of the portal
+	InternetServices InternetServices          `json:"internetservices"` // Connection state of internet services
+	FactoryNew       bool                      `json:"factorynew"`       // Whether the bridge is factory new
+	ReplacesBridgeID string                    `json:"replacesbridgeid"` // Id of the bridge this one replaced from backup
+	StarterKitID     string                    `json:"starterkitid"`     // Name of the starter kit
+	Whitelist        map[string]WhitelistEntry `json:"whitelist"`        // Registered users by username
+}
+
+// WhitelistEntry is a user which is allowed to access the bridge
+type WhitelistEntry struct {
+	Name        string `json:"name"`
+	CreateDate  string `json:"create date"`
+	LastUseDate string `json:"last use date"`
+}
+
+// SWUpdate2 is the software update state of the bridge and the devices
+type SWUpdate2 struct {
+	CheckForUpdate bool          `json:"checkforupdate"` // Bridge is checking for updates, false when done
+	LastChange     string        `json:"lastchange"`
+	State          string        `json:"state"` // Overall state, one of SWUpdateState*
+	Bridge         SWUpdate      `json:"bridge"`
+	AutoInstall    SWAutoInstall `json:"autoinstall"`
+}
+
+// SWAutoInstall configures automatic software updates
+type SWAutoInstall struct {
+	On         bool   `json:"on"`
+	UpdateTime string `json:"updatetime,omitempty"` // e.g. T14:00:00
+}
+
+type PortalState struct {
+	SignedOn      bool   `json:"signedon"`
+	Incoming      bool   `json:"incoming"`
+	Outgoing      bool   `json:"outgoing"`
+	Communication string `json:"communication"`
+}
+
+type InternetServices struct {
+	Internet     string `json:"internet"`
+	RemoteAccess string `json:"remoteaccess"`
+	Time         string `json:"time"`
+	SWUpdate     string `json:"swupdate"`
+}
+
+// ConfigParams is used to update the configuration, nil fields are not changed.
+type ConfigParams struct {
+	Name          *string          `json:"name,omitempty"`
+	ZigbeeChannel *int             `json:"zigbeechannel,omitempty"`
+	DHCP          *bool            `json:"dhcp,omitempty"`
+	IPAddress     *string          `json:"ipaddress,omitempty"`
+	Netmask       *string          `json:"netmask,omitempty"`
+	Gateway       *string          `json:"gateway,omitempty"`
+	ProxyAddress  *string          `json:"proxyaddress,omitempty"`
+	ProxyPort     *int             `json:"proxyport,omitempty"`
+	LinkButton    *bool            `json:"linkbutton,omitempty"`
+	TouchLink     *bool            `json:"touchlink,omitempty"`
+	Timezone      *string          `json:"timezone,omitempty"`
+	SWUpdate2     *SWUpdate2Params `json:"swupdate2,omitempty"`
+}
+
+// SWUpdate2Params is used to check for and install software updates
+type SWUpdate2Params struct {
+	CheckForUpdate *bool          `json:"checkforupdate,omitempty"`
+	Install        *bool          `json:"install,omitempty"`
+	AutoInstall    *SWAutoInstall `json:"autoinstall,omitempty"`
+}
@@ -0,0 +1,87 @@
+package hue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Config_Get.json")
+	mux.HandleFunc("/username/config", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Config.Get(ctx)
+	if err != nil {
+		t.Errorf("Config.Get returned error: %+v", err)
+	}
+
+	var want BridgeConfig
+	json.Unmarshal(bytes, &want)
+
+	assert.Equal(t, &want, got)
+	assert.Equal(t, "001788FFFE23BFC2", got.BridgeID)
+	assert.Equal(t, 15, got.ZigbeeChannel)
+	assert.Equal(t, SWUpdateStateAnyReadyToInstall, got.SWUpdate2.State)
+	assert.True(t, got.SWUpdate2.AutoInstall.On)
+	assert.True(t, got.PortalState.SignedOn)
+	assert.Equal(t, "connected", got.InternetServices.RemoteAccess)
+	assert.Len(t, got.Whitelist, 2)
+	assert.Equal(t, "go-hue#client", got.Whitelist["username"].Name)
+}
+
+func TestConfigService_Update(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Config_Update.json")
+	mux.HandleFunc("/username/config", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, map[string]interface{}{"name": "Living room bridge", "zigbeechannel": float64(20)}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Config.Update(ctx, ConfigParams{Name: String("Living room bridge"), ZigbeeChannel: Int(20)})
+	if err != nil {
+		t.Errorf("Config.Update returned error: %+v", err)
+	}
+
+	assert.Len(t, got, 2)
+}
+
+func TestConfigService_DeleteWhitelist(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Config_DeleteWhitelist.json")
+	mux.HandleFunc("/username/config/whitelist/83b7780291a6ceffbe0bd049104df", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, err := client.Config.DeleteWhitelist(ctx, "83b7780291a6ceffbe0bd049104df")
+	if err != nil {
+		t.Errorf("Config.DeleteWhitelist returned error: %+v", err)
+	}
+}
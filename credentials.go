@@ -0,0 +1,233 @@
+package hue
+
+import (
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrCredentialsNotFound is returned by a CredentialStore which has no credentials for the bridge
+var ErrCredentialsNotFound = errors.New("hue: no credentials stored for the bridge")
+
+// Credentials are returned by the bridge when a user is created
+type Credentials struct {
+	Username  string        `json:"username"`        // Username used as clientId for the API
+	ClientKey string        `json:"clientkey"`       // Hex encoded PSK for entertainment streaming
+	Token     *oauth2.Token `json:"token,omitempty"` // OAuth token of the remote API
+
+	// SHA-256 of the certificate pinned by TLSOptions.TrustOnFirstUse
+	CertificateFingerprint string `json:"certificatefingerprint,omitempty"`
+}
+
+// CredentialStore keeps credentials between restarts, keyed by bridge ID e.g. 001788FFFE23BFC2
+type CredentialStore interface {
+	// Load returns ErrCredentialsNotFound if nothing is stored for the bridge
+	Load(bridgeID string) (*Credentials, error)
+	Save(bridgeID string, creds *Credentials) error
+	Delete(bridgeID string) error
+}
+
+// MemoryCredentialStore keeps credentials in memory, e.g. for tests
+type MemoryCredentialStore struct {
+	mu    sync.Mutex
+	creds map[string]Credentials
+}
+
+// NewMemoryCredentialStore returns an empty MemoryCredentialStore
+func NewMemoryCredentialStore() *MemoryCredentialStore {
+	return &MemoryCredentialStore{creds: make(map[string]Credentials)}
+}
+
+func (s *MemoryCredentialStore) Load(bridgeID string) (*Credentials, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	creds, ok := s.creds[normalizeBridgeID(bridgeID)]
+	if !ok {
+		return nil, ErrCredentialsNotFound
+	}
+	return &creds, nil
+}
+
+func (s *MemoryCredentialStore) Save(bridgeID string, creds *Credentials) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.creds[normalizeBridgeID(bridgeID)] = *creds
+	return nil
+}
+
+func (s *MemoryCredentialStore) Delete(bridgeID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.creds, normalizeBridgeID(bridgeID))
+	return nil
+}
+
+// FileCredentialStore keeps credentials of every bridge in a JSON file which only the owner can read
+type FileCredentialStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileCredentialStore returns a store which keeps credentials in the file at path.
+// The file and its directory are created on the first Save.
+func NewFileCredentialStore(path string) *FileCredentialStore {
+	return &FileCredentialStore{path: path}
+}
+
+// DefaultCredentialsPath returns go-hue/credentials.json in the user config directory, e.g. ~/.config on Linux
+func DefaultCredentialsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "go-hue", "credentials.json"), nil
+}
+
+func (s *FileCredentialStore) Load(bridgeID string) (*Credentials, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return nil, err
+	}
+
+	creds, ok := all[normalizeBridgeID(bridgeID)]
+	if !ok {
+		return nil, ErrCredentialsNotFound
+	}
+	return &creds, nil
+}
+
+func (s *FileCredentialStore) Save(bridgeID string, creds *Credentials) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	all[normalizeBridgeID(bridgeID)] = *creds
+
+	return s.write(all)
+}
+
+func (s *FileCredentialStore) Delete(bridgeID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	all, err := s.read()
+	if err != nil {
+		return err
+	}
+	if _, ok := all[normalizeBridgeID(bridgeID)]; !ok {
+		return nil
+	}
+	delete(all, normalizeBridgeID(bridgeID))
+
+	return s.write(all)
+}
+
+// read returns the stored credentials, a missing file is an empty store
+func (s *FileCredentialStore) read() (map[string]Credentials, error) {
+	all := make(map[string]Credentials)
+
+	data, err := ioutil.ReadFile(s.path)
+	if os.IsNotExist(err) {
+		return all, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(data, &all); err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// write replaces the file through a temporary file, so a failed write doesn't lose the stored credentials
+func (s *FileCredentialStore) write(all map[string]Credentials) error {
+	data, err := json.MarshalIndent(all, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	dir := filepath.Dir(s.path)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(dir, ".credentials-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	// TempFile creates the file with 0600 already, Chmod guards against other implementations
+	if err := tmp.Chmod(0600); err != nil {
+		tmp.Close()
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), s.path)
+}
+
+// updateCredentials changes the stored credentials of the bridge with update, nothing stored yet is an empty Credentials
+func updateCredentials(store CredentialStore, bridgeID string, update func(creds *Credentials)) error {
+	creds, err := store.Load(bridgeID)
+	if errors.Is(err, ErrCredentialsNotFound) {
+		creds, err = &Credentials{}, nil
+	}
+	if err != nil {
+		return err
+	}
+
+	update(creds)
+	return store.Save(bridgeID, creds)
+}
+
+// storeTokenSource saves every new token of src, so refreshed tokens are used after a restart
+type storeTokenSource struct {
+	src      oauth2.TokenSource
+	store    CredentialStore
+	bridgeID string
+
+	mu   sync.Mutex
+	last string // Access token saved last
+}
+
+func (s *storeTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if token.AccessToken != s.last {
+		err := updateCredentials(s.store, s.bridgeID, func(creds *Credentials) { creds.Token = token })
+		if err != nil {
+			return nil, err
+		}
+		s.last = token.AccessToken
+	}
+
+	return token, nil
+}
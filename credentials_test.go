@@ -0,0 +1,184 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+var testBridgeId = "001788FFFE23BFC2"
+
+func TestFileCredentialStore(t *testing.T) {
+	dir, _ := ioutil.TempDir("", "go-hue")
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "go-hue", "credentials.json")
+	store := NewFileCredentialStore(path)
+
+	_, err := store.Load(testBridgeId)
+	assert.True(t, errors.Is(err, ErrCredentialsNotFound))
+
+	creds := &Credentials{Username: "username", ClientKey: "321C0C2EBFA7361EDC8D3F4B3D5BFE2D"}
+	assert.NoError(t, store.Save(testBridgeId, creds))
+	assert.NoError(t, store.Save("ECB5FAFFFE0A1B2C", &Credentials{Username: "other"}))
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("Credentials file wasn't created: %v", err)
+	}
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+
+	// A new store reads the same file, bridge ids are case insensitive
+	got, err := NewFileCredentialStore(path).Load("001788fffe23bfc2")
+	assert.NoError(t, err)
+	assert.Equal(t, creds, got)
+
+	assert.NoError(t, store.Delete(testBridgeId))
+	_, err = store.Load(testBridgeId)
+	assert.True(t, errors.Is(err, ErrCredentialsNotFound))
+
+	got, err = store.Load("ECB5FAFFFE0A1B2C")
+	assert.NoError(t, err)
+	assert.Equal(t, "other", got.Username)
+}
+
+func TestMemoryCredentialStore(t *testing.T) {
+	store := NewMemoryCredentialStore()
+
+	_, err := store.Load(testBridgeId)
+	assert.True(t, errors.Is(err, ErrCredentialsNotFound))
+
+	assert.NoError(t, store.Save(testBridgeId, &Credentials{Username: "username"}))
+	got, err := store.Load(testBridgeId)
+	assert.NoError(t, err)
+	assert.Equal(t, "username", got.Username)
+
+	// The stored credentials can't be changed through the returned value
+	got.Username = "changed"
+	got, _ = store.Load(testBridgeId)
+	assert.Equal(t, "username", got.Username)
+
+	assert.NoError(t, store.Delete(testBridgeId))
+	_, err = store.Load(testBridgeId)
+	assert.True(t, errors.Is(err, ErrCredentialsNotFound))
+}
+
+func TestNewClient_CredentialStore(t *testing.T) {
+	store := NewMemoryCredentialStore()
+	store.Save(testBridgeId, &Credentials{Username: "stored", ClientKey: "321C0C2EBFA7361EDC8D3F4B3D5BFE2D"})
+
+	client := NewClient("localhost", "", &ClientOptions{CredentialStore: store, BridgeID: testBridgeId})
+	assert.Equal(t, "stored", client.GetClientID())
+	assert.Equal(t, "321C0C2EBFA7361EDC8D3F4B3D5BFE2D", client.GetClientKey())
+
+	// An explicit clientId wins
+	client = NewClient("localhost", "username", &ClientOptions{CredentialStore: store, BridgeID: testBridgeId})
+	assert.Equal(t, "username", client.GetClientID())
+}
+
+func handlePublicConfig(t *testing.T, mux *http.ServeMux) {
+	bytes, _ := ioutil.ReadFile("testdata/Bridge_PublicConfig.json")
+	mux.HandleFunc("/0/config", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+}
+
+func TestPair_CredentialStore(t *testing.T) {
+	_, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	handlePublicConfig(t, mux)
+	attempts := handleCreateUser(t, mux, 1)
+
+	store := NewMemoryCredentialStore()
+	opts := &PairOptions{ClientOptions: &ClientOptions{CredentialStore: store}}
+
+	u, _ := url.Parse(serverURL)
+	got, err := Pair(context.Background(), u.Host, "go-hue#test", opts)
+	if err != nil {
+		t.Fatalf("Pair returned error: %+v", err)
+	}
+
+	stored, err := store.Load(testBridgeId)
+	assert.NoError(t, err)
+	assert.Equal(t, got, stored)
+
+	// The stored user is reused
+	again, err := Pair(context.Background(), u.Host, "go-hue#test", opts)
+	assert.NoError(t, err)
+	assert.Equal(t, got, again)
+	assert.Equal(t, 1, *attempts)
+}
+
+type testTokenSource struct {
+	tokens []*oauth2.Token
+}
+
+func (s *testTokenSource) Token() (*oauth2.Token, error) {
+	token := s.tokens[0]
+	if len(s.tokens) > 1 {
+		s.tokens = s.tokens[1:]
+	}
+	return token, nil
+}
+
+func TestStoreTokenSource(t *testing.T) {
+	store := NewMemoryCredentialStore()
+	store.Save(testBridgeId, &Credentials{Username: "username"})
+
+	first := &oauth2.Token{AccessToken: "first", Expiry: time.Now()}
+	refreshed := &oauth2.Token{AccessToken: "refreshed", RefreshToken: "refresh", Expiry: time.Now().Add(time.Hour)}
+	src := &storeTokenSource{
+		src:      &testTokenSource{tokens: []*oauth2.Token{first, refreshed}},
+		store:    store,
+		bridgeID: testBridgeId,
+		last:     "first",
+	}
+
+	token, err := src.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "first", token.AccessToken)
+
+	creds, _ := store.Load(testBridgeId)
+	assert.Nil(t, creds.Token)
+
+	token, err = src.Token()
+	assert.NoError(t, err)
+	assert.Equal(t, "refreshed", token.AccessToken)
+
+	creds, _ = store.Load(testBridgeId)
+	assert.Equal(t, "username", creds.Username)
+	assert.Equal(t, "refresh", creds.Token.RefreshToken)
+}
+
+func TestAuthenticator_StoredToken(t *testing.T) {
+	store := NewMemoryCredentialStore()
+	store.Save(testBridgeId, &Credentials{
+		Username: "username",
+		Token:    &oauth2.Token{AccessToken: "access", TokenType: "Bearer", Expiry: time.Now().Add(time.Hour)},
+	})
+
+	auth := NewAuthenticatorWithOptions(&AuthenticatorOptions{RedirectURL: "http://localhost:8181/callback"})
+	auth.SetCredentialStore(store, testBridgeId)
+
+	// No browser is opened since the stored token is used
+	client, err := auth.Authenticate()
+	if err != nil {
+		t.Fatalf("Authenticate returned error: %+v", err)
+	}
+
+	assert.Equal(t, "username", client.GetClientID())
+	assert.Equal(t, "access", auth.GetToken().AccessToken)
+}
@@ -0,0 +1,54 @@
+package hue
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+)
+
+// GetFullState returns lights, groups, config, schedules, scenes, rules, sensors and resource links
+// with a single request, so all of them are from the same moment.
+func (c *Client) GetFullState(ctx context.Context) (*Datastore, *Response, error) {
+	req, err := c.newRequest(http.MethodGet, c.clientId, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ds datastore
+	resp, err := c.do(ctx, req, &ds)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	state := &Datastore{Config: ds.Config}
+	for k, l := range ds.Lights {
+		l.ID, _ = strconv.Atoi(k)
+		state.Lights = append(state.Lights, l)
+	}
+	for k, g := range ds.Groups {
+		g.ID, _ = strconv.Atoi(k)
+		state.Groups = append(state.Groups, g)
+	}
+	for k, s := range ds.Schedules {
+		s.ID, _ = strconv.Atoi(k)
+		state.Schedules = append(state.Schedules, s)
+	}
+	for k, s := range ds.Scenes {
+		s.ID = k
+		state.Scenes = append(state.Scenes, s)
+	}
+	for k, r := range ds.Rules {
+		r.ID, _ = strconv.Atoi(k)
+		state.Rules = append(state.Rules, r)
+	}
+	for k, s := range ds.Sensors {
+		s.ID, _ = strconv.Atoi(k)
+		state.Sensors = append(state.Sensors, s)
+	}
+	for k, l := range ds.ResourceLinks {
+		l.ID, _ = strconv.Atoi(k)
+		state.ResourceLinks = append(state.ResourceLinks, l)
+	}
+
+	return state, resp, nil
+}
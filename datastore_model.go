@@ -0,0 +1,27 @@
+package hue
+
+// Datastore struct that represents the whole state of the bridge
+//
+// Groups doesn't contain the special group 0, same as GroupService.GetAll.
+type Datastore struct {
+	Lights        []Light
+	Groups        []Group
+	Config        BridgeConfig
+	Schedules     []Schedule
+	Scenes        []Scene
+	Rules         []Rule
+	Sensors       []Sensor
+	ResourceLinks []ResourceLink
+}
+
+// datastore is the response of the bridge, resources are keyed by their ids
+type datastore struct {
+	Lights        map[string]Light        `json:"lights"`
+	Groups        map[string]Group        `json:"groups"`
+	Config        BridgeConfig            `json:"config"`
+	Schedules     map[string]Schedule     `json:"schedules"`
+	Scenes        map[string]Scene        `json:"scenes"`
+	Rules         map[string]Rule         `json:"rules"`
+	Sensors       map[string]Sensor       `json:"sensors"`
+	ResourceLinks map[string]ResourceLink `json:"resourcelinks"`
+}
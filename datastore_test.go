@@ -0,0 +1,73 @@
+package hue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient_GetFullState(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Datastore_Get.json")
+	mux.HandleFunc("/username", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.GetFullState(ctx)
+	if err != nil {
+		t.Errorf("Client.GetFullState returned error: %+v", err)
+	}
+
+	var result map[string]json.RawMessage
+	json.Unmarshal(bytes, &result)
+
+	var lights map[string]Light
+	json.Unmarshal(result["lights"], &lights)
+	assert.Len(t, got.Lights, len(lights))
+	for _, l := range got.Lights {
+		want := lights[fmt.Sprint(l.ID)]
+		want.ID = l.ID
+		if !cmp.Equal(l, want) {
+			t.Errorf("Client.GetFullState returned light %+v, want %+v", l, want)
+		}
+	}
+
+	var config BridgeConfig
+	json.Unmarshal(result["config"], &config)
+	assert.Equal(t, config, got.Config)
+
+	sort.Slice(got.Groups, func(i, j int) bool { return got.Groups[i].ID < got.Groups[j].ID })
+	assert.Equal(t, 1, got.Groups[0].ID)
+	assert.Len(t, got.Schedules, 2)
+	assert.Len(t, got.Rules, 2)
+	assert.Len(t, got.Sensors, 8)
+
+	sort.Slice(got.Scenes, func(i, j int) bool { return got.Scenes[i].ID < got.Scenes[j].ID })
+	assert.Equal(t, testSceneId, got.Scenes[0].ID)
+
+	sort.Slice(got.ResourceLinks, func(i, j int) bool { return got.ResourceLinks[i].ID < got.ResourceLinks[j].ID })
+	assert.Equal(t, 8126, got.ResourceLinks[0].ID)
+	assert.Equal(t, []string{"/schedules/1", "/scenes/3T2SvsxvwteNNys", "/rules/1"}, got.ResourceLinks[0].Links)
+
+	for _, s := range got.Sensors {
+		assert.NotZero(t, s.ID)
+	}
+	for _, r := range got.Rules {
+		assert.NotZero(t, r.ID)
+	}
+	for _, s := range got.Schedules {
+		assert.NotZero(t, s.ID)
+	}
+}
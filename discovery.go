@@ -0,0 +1,408 @@
+package hue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultDiscoveryTimeout is how long local discovery waits for answers
+const defaultDiscoveryTimeout = 3 * time.Second
+
+// probeTimeout is how long a candidate may take to answer /api/0/config
+var probeTimeout = 3 * time.Second
+
+// ErrNoBridgeFound is returned when discovery couldn't find any bridge
+var ErrNoBridgeFound = errors.New("hue: no bridge found on your network")
+
+// Bridge is a Hue bridge found on the network
+type Bridge struct {
+	ID    string // Bridge ID, e.g. 001788FFFE23BFC2
+	Host  string // IP address of the bridge
+	Port  int    // Port of the bridge API, 0 if unknown
+	Model string // Model ID, e.g. BSB002. Not every discovery method provides it
+
+	// Following fields are only set by DiscoverAll and ProbeBridge
+	Name       string // Name of the bridge, e.g. Philips hue
+	APIVersion string // Version of the API, e.g. 1.35.0
+	SWVersion  string // Software version of the bridge, e.g. 1935144040
+	MAC        string // MAC address of the bridge, e.g. 00:17:88:23:bf:c2
+}
+
+// bridgeConfig is the public part of /api/config, which doesn't require a username
+type bridgeConfig struct {
+	Name       string `json:"name"`
+	BridgeID   string `json:"bridgeid"`
+	ModelID    string `json:"modelid"`
+	APIVersion string `json:"apiversion"`
+	SWVersion  string `json:"swversion"`
+	MAC        string `json:"mac"`
+}
+
+// Addr returns the address of the bridge which can be passed to NewClient.
+// Bridges serve the API on both 80 and 443, so the port is only added if it is different.
+func (b Bridge) Addr() string {
+	if b.Port == 0 || b.Port == 80 || b.Port == 443 {
+		return b.Host
+	}
+	return fmt.Sprintf("%v:%d", b.Host, b.Port)
+}
+
+// Discoverer finds bridges with one discovery method
+type Discoverer interface {
+	Discover(ctx context.Context) ([]Bridge, error)
+}
+
+// DefaultDiscoverers are used when no discoverer is given to DiscoverBridges.
+// Local methods are preferred, the cloud endpoint is the last resort.
+func DefaultDiscoverers() []Discoverer {
+	return []Discoverer{
+		&MDNSDiscoverer{},
+		&SSDPDiscoverer{},
+		&CloudDiscoverer{},
+	}
+}
+
+// DiscoverBridges runs the discoverers in order and returns all bridges found by the first one that finds any.
+// DefaultDiscoverers is used if no discoverer is given.
+func DiscoverBridges(ctx context.Context, discoverers ...Discoverer) ([]Bridge, error) {
+	if len(discoverers) == 0 {
+		discoverers = DefaultDiscoverers()
+	}
+
+	var errs []string
+	for _, d := range discoverers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		bridges, err := d.Discover(ctx)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if bridges = uniqueBridges(bridges); len(bridges) > 0 {
+			return bridges, nil
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNoBridgeFound, strings.Join(errs, "; "))
+	}
+	return nil, ErrNoBridgeFound
+}
+
+// Discover gets hue bridge host address
+// It returns the first bridge found without waiting for others, use DiscoverBridges if there may be more than one.
+func Discover() (string, error) {
+	bridge, err := discoverFirst(context.Background(), DefaultDiscoverers()...)
+	if err != nil {
+		return "", err
+	}
+
+	return bridge.Addr(), nil
+}
+
+// eachDiscoverer is implemented by discoverers which can report bridges while they are still waiting for answers
+type eachDiscoverer interface {
+	discoverEach(ctx context.Context, found func(Bridge)) error
+}
+
+// discoverFirst runs the discoverers in order like DiscoverBridges,
+// but returns as soon as a discoverer reports a bridge with ID.
+func discoverFirst(ctx context.Context, discoverers ...Discoverer) (*Bridge, error) {
+	var errs []string
+	for _, d := range discoverers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		if each, ok := d.(eachDiscoverer); ok {
+			// Bridges without ID, e.g. with old firmware, are only used if no other bridge answers
+			var first, withoutID *Bridge
+			browseCtx, cancel := context.WithCancel(ctx)
+			err := each.discoverEach(browseCtx, func(b Bridge) {
+				switch {
+				case first != nil:
+				case b.ID != "":
+					first = &b
+					// Stops waiting for other answers
+					cancel()
+				case withoutID == nil:
+					withoutID = &b
+				}
+			})
+			cancel()
+			if first != nil {
+				return first, nil
+			}
+			if withoutID != nil {
+				return withoutID, nil
+			}
+			if err != nil {
+				errs = append(errs, err.Error())
+			}
+			continue
+		}
+
+		bridges, err := d.Discover(ctx)
+		if err != nil {
+			errs = append(errs, err.Error())
+			continue
+		}
+		if bridges = uniqueBridges(bridges); len(bridges) > 0 {
+			return &bridges[0], nil
+		}
+	}
+
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNoBridgeFound, strings.Join(errs, "; "))
+	}
+	return nil, ErrNoBridgeFound
+}
+
+// DiscoverAll discovers bridges like DiscoverBridges and verifies every candidate through /api/0/config.
+// Candidates which don't answer like a Hue bridge within a few seconds are dropped,
+// the others are returned with their identity filled from the bridge itself.
+// If no candidate is a bridge, ErrNoBridgeFound is returned with the reason of every candidate.
+func DiscoverAll(ctx context.Context, discoverers ...Discoverer) ([]Bridge, error) {
+	candidates, err := DiscoverBridges(ctx, discoverers...)
+	if err != nil {
+		return nil, err
+	}
+
+	verified := make([]*Bridge, len(candidates))
+	errs := make([]error, len(candidates))
+	var wg sync.WaitGroup
+	for i, c := range candidates {
+		wg.Add(1)
+		go func(i int, c Bridge) {
+			defer wg.Done()
+			verified[i], errs[i] = probeBridge(ctx, http.DefaultClient, "http", c)
+		}(i, c)
+	}
+	wg.Wait()
+
+	var bridges []Bridge
+	var msgs []string
+	for i, b := range verified {
+		if b != nil {
+			bridges = append(bridges, *b)
+		} else {
+			msgs = append(msgs, errs[i].Error())
+		}
+	}
+	if len(bridges) == 0 {
+		return nil, fmt.Errorf("%w: %v", ErrNoBridgeFound, strings.Join(msgs, "; "))
+	}
+
+	return uniqueBridges(bridges), nil
+}
+
+// FindBridge returns the bridge with given id
+func FindBridge(bridges []Bridge, id string) (Bridge, bool) {
+	id = normalizeBridgeID(id)
+	for _, b := range bridges {
+		if b.ID == id {
+			return b, true
+		}
+	}
+	return Bridge{}, false
+}
+
+// ProbeBridge reads the public configuration of the bridge at addr, e.g. 192.168.1.10
+// It returns an error if addr is not a Hue bridge.
+func ProbeBridge(ctx context.Context, addr string) (*Bridge, error) {
+	return probeBridge(ctx, http.DefaultClient, "http", bridgeAt(addr))
+}
+
+// bridgeAt returns the bridge at addr, which may have a port
+func bridgeAt(addr string) Bridge {
+	host, port := addr, 0
+	if h, p, err := net.SplitHostPort(addr); err == nil {
+		host = h
+		port, _ = strconv.Atoi(p)
+	}
+	return Bridge{Host: host, Port: port}
+}
+
+func probeBridge(ctx context.Context, httpClient *http.Client, scheme string, b Bridge) (*Bridge, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%v://%v/api/0/config", scheme, b.Addr()), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	// An unresponsive device mustn't block discovery
+	ctx, cancel := context.WithTimeout(ctx, probeTimeout)
+	defer cancel()
+
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("hue: %v didn't answer: %w", b.Addr(), err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("hue: %v returned %v", b.Addr(), resp.Status)
+	}
+
+	var config bridgeConfig
+	if err := json.NewDecoder(resp.Body).Decode(&config); err != nil {
+		return nil, fmt.Errorf("hue: %v is not a hue bridge: %w", b.Addr(), err)
+	}
+	if config.BridgeID == "" || config.APIVersion == "" {
+		return nil, fmt.Errorf("hue: %v is not a hue bridge", b.Addr())
+	}
+
+	b.ID = normalizeBridgeID(config.BridgeID)
+	b.Model = config.ModelID
+	b.Name = config.Name
+	b.APIVersion = config.APIVersion
+	b.SWVersion = config.SWVersion
+	b.MAC = config.MAC
+
+	return &b, nil
+}
+
+// uniqueBridges removes bridges reported more than once, e.g. for each network interface
+func uniqueBridges(bridges []Bridge) []Bridge {
+	seen := make(map[string]bool)
+	result := make([]Bridge, 0, len(bridges))
+	for _, b := range bridges {
+		key := b.ID
+		if key == "" {
+			key = b.Addr()
+		}
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		result = append(result, b)
+	}
+	return result
+}
+
+// normalizeBridgeID returns bridge id in the format of /api/config, e.g. 001788FFFE23BFC2
+func normalizeBridgeID(id string) string {
+	return strings.ToUpper(strings.TrimSpace(id))
+}
+
+// multicastQuery sends query to addr and passes every answer to handle until the timeout expires.
+// It returns nil when the timeout expires, cancellation of ctx stops it earlier.
+func multicastQuery(ctx context.Context, addr string, timeout time.Duration, query []byte, handle func(from *net.UDPAddr, msg []byte)) error {
+	raddr, err := net.ResolveUDPAddr("udp4", addr)
+	if err != nil {
+		return err
+	}
+
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{})
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if timeout <= 0 {
+		timeout = defaultDiscoveryTimeout
+	}
+	deadline := time.Now().Add(timeout)
+	if d, ok := ctx.Deadline(); ok && d.Before(deadline) {
+		deadline = d
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		return err
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+	go func() {
+		select {
+		case <-ctx.Done():
+			conn.SetDeadline(time.Now())
+		case <-done:
+		}
+	}()
+
+	if _, err := conn.WriteTo(query, raddr); err != nil {
+		return err
+	}
+
+	buf := make([]byte, 65536)
+	for {
+		n, from, err := conn.ReadFromUDP(buf)
+		if err != nil {
+			if ne, ok := err.(net.Error); ok && ne.Timeout() {
+				return nil
+			}
+			return err
+		}
+		handle(from, buf[:n])
+	}
+}
+
+type discoverResponse struct {
+	ID   string `json:"id"`
+	Host string `json:"internalipaddress"`
+	Port int    `json:"port"`
+}
+
+// CloudDiscoverer uses the N-UPnP endpoint of Hue, it requires internet access.
+type CloudDiscoverer struct {
+	URL        string       // Defaults to https://discovery.meethue.com/
+	HttpClient *http.Client // Defaults to http.DefaultClient
+}
+
+func (d *CloudDiscoverer) Discover(ctx context.Context) ([]Bridge, error) {
+	u := d.URL
+	if u == "" {
+		u = discoveryUrl
+	}
+	httpClient := d.HttpClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req, err := http.NewRequest(http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.New("invalid status code returned")
+	}
+
+	bytes, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	var discoveryResponses []discoverResponse
+	err = json.Unmarshal(bytes, &discoveryResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	bridges := make([]Bridge, 0, len(discoveryResponses))
+	for _, r := range discoveryResponses {
+		bridges = append(bridges, Bridge{ID: normalizeBridgeID(r.ID), Host: r.Host, Port: r.Port})
+	}
+
+	return bridges, nil
+}
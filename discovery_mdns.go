@@ -0,0 +1,200 @@
+package hue
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+const (
+	mdnsAddr    = "224.0.0.251:5353"
+	mdnsService = "_hue._tcp.local."
+)
+
+// MDNSDiscoverer browses _hue._tcp services on the local network
+type MDNSDiscoverer struct {
+	Addr    string        // Defaults to 224.0.0.251:5353
+	Timeout time.Duration // How long to wait for answers, defaults to 3 seconds
+}
+
+// mdnsInstance collects the records of a single service instance
+type mdnsInstance struct {
+	target   string
+	port     int
+	bridgeID string
+	modelID  string
+	from     net.IP
+}
+
+func (d *MDNSDiscoverer) Discover(ctx context.Context) ([]Bridge, error) {
+	var bridges []Bridge
+	err := d.discoverEach(ctx, func(b Bridge) {
+		bridges = append(bridges, b)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uniqueBridges(bridges), nil
+}
+
+// discoverEach passes every bridge to found as soon as its ID and address are known,
+// the incomplete ones are passed when the timeout expires
+func (d *MDNSDiscoverer) discoverEach(ctx context.Context, found func(Bridge)) error {
+	addr := d.Addr
+	if addr == "" {
+		addr = mdnsAddr
+	}
+
+	query, err := mdnsQuery()
+	if err != nil {
+		return err
+	}
+
+	instances := make(map[string]*mdnsInstance)
+	hosts := make(map[string]net.IP)
+	reported := make(map[*mdnsInstance]bool)
+	report := func(complete bool) {
+		for _, in := range instances {
+			if reported[in] || (complete && (in.bridgeID == "" || hosts[in.target] == nil)) {
+				continue
+			}
+			reported[in] = true
+			found(in.bridge(hosts))
+		}
+	}
+
+	err = multicastQuery(ctx, addr, d.Timeout, query, func(from *net.UDPAddr, msg []byte) {
+		parseMDNSResponse(msg, from.IP, instances, hosts)
+		report(true)
+	})
+	if err != nil {
+		return err
+	}
+	report(false)
+
+	return nil
+}
+
+// bridge returns the bridge of the instance, at the address it answered from if its host is unknown
+func (in *mdnsInstance) bridge(hosts map[string]net.IP) Bridge {
+	ip := hosts[in.target]
+	if ip == nil {
+		ip = in.from
+	}
+	return Bridge{
+		ID:    normalizeBridgeID(in.bridgeID),
+		Host:  ip.String(),
+		Port:  in.port,
+		Model: in.modelID,
+	}
+}
+
+func mdnsQuery() ([]byte, error) {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{})
+	if err := b.StartQuestions(); err != nil {
+		return nil, err
+	}
+	// Ask for unicast response (QU bit), so answers come back to our port
+	err := b.Question(dnsmessage.Question{
+		Name:  dnsmessage.MustNewName(mdnsService),
+		Type:  dnsmessage.TypePTR,
+		Class: dnsmessage.ClassINET | 1<<15,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return b.Finish()
+}
+
+// parseMDNSResponse reads the records of every section, responders put SRV, TXT and A records in additionals
+func parseMDNSResponse(msg []byte, from net.IP, instances map[string]*mdnsInstance, hosts map[string]net.IP) {
+	var p dnsmessage.Parser
+	if _, err := p.Start(msg); err != nil {
+		return
+	}
+	if err := p.SkipAllQuestions(); err != nil {
+		return
+	}
+
+	instance := func(name string) *mdnsInstance {
+		in, ok := instances[name]
+		if !ok {
+			in = &mdnsInstance{from: from}
+			instances[name] = in
+		}
+		return in
+	}
+
+	sections := []struct {
+		header func() (dnsmessage.ResourceHeader, error)
+		skip   func() error
+	}{
+		{p.AnswerHeader, p.SkipAnswer},
+		{p.AuthorityHeader, p.SkipAuthority},
+		{p.AdditionalHeader, p.SkipAdditional},
+	}
+	for _, section := range sections {
+		for {
+			h, err := section.header()
+			if err != nil {
+				break
+			}
+			name := strings.ToLower(h.Name.String())
+
+			switch h.Type {
+			case dnsmessage.TypePTR:
+				r, err := p.PTRResource()
+				if err != nil {
+					return
+				}
+				if name == mdnsService {
+					instance(strings.ToLower(r.PTR.String()))
+				}
+			case dnsmessage.TypeSRV:
+				r, err := p.SRVResource()
+				if err != nil {
+					return
+				}
+				if strings.HasSuffix(name, "."+mdnsService) {
+					in := instance(name)
+					in.target = strings.ToLower(r.Target.String())
+					in.port = int(r.Port)
+				}
+			case dnsmessage.TypeTXT:
+				r, err := p.TXTResource()
+				if err != nil {
+					return
+				}
+				if strings.HasSuffix(name, "."+mdnsService) {
+					in := instance(name)
+					for _, txt := range r.TXT {
+						kv := strings.SplitN(txt, "=", 2)
+						if len(kv) != 2 {
+							continue
+						}
+						switch strings.ToLower(kv[0]) {
+						case "bridgeid":
+							in.bridgeID = kv[1]
+						case "modelid":
+							in.modelID = kv[1]
+						}
+					}
+				}
+			case dnsmessage.TypeA:
+				r, err := p.AResource()
+				if err != nil {
+					return
+				}
+				hosts[name] = net.IP(r.A[:])
+			default:
+				if err := section.skip(); err != nil {
+					return
+				}
+			}
+		}
+	}
+}
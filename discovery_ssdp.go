@@ -0,0 +1,79 @@
+package hue
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const ssdpAddr = "239.255.255.250:1900"
+
+const ssdpSearch = "M-SEARCH * HTTP/1.1\r\n" +
+	"HOST: 239.255.255.250:1900\r\n" +
+	"MAN: \"ssdp:discover\"\r\n" +
+	"MX: 2\r\n" +
+	"ST: ssdp:all\r\n" +
+	"\r\n"
+
+// SSDPDiscoverer sends UPnP M-SEARCH requests and picks the answers of Hue bridges
+type SSDPDiscoverer struct {
+	Addr    string        // Defaults to 239.255.255.250:1900
+	Timeout time.Duration // How long to wait for answers, defaults to 3 seconds
+}
+
+func (d *SSDPDiscoverer) Discover(ctx context.Context) ([]Bridge, error) {
+	var bridges []Bridge
+	err := d.discoverEach(ctx, func(b Bridge) {
+		bridges = append(bridges, b)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return uniqueBridges(bridges), nil
+}
+
+// discoverEach passes every bridge to found as soon as it answers
+func (d *SSDPDiscoverer) discoverEach(ctx context.Context, found func(Bridge)) error {
+	addr := d.Addr
+	if addr == "" {
+		addr = ssdpAddr
+	}
+
+	return multicastQuery(ctx, addr, d.Timeout, []byte(ssdpSearch), func(from *net.UDPAddr, msg []byte) {
+		if b, ok := parseSSDPResponse(msg, from.IP); ok {
+			found(b)
+		}
+	})
+}
+
+// parseSSDPResponse returns the bridge if msg is an answer of a Hue bridge.
+// Other UPnP devices on the network answer as well, they are ignored.
+func parseSSDPResponse(msg []byte, from net.IP) (Bridge, bool) {
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(msg)), nil)
+	if err != nil {
+		return Bridge{}, false
+	}
+	resp.Body.Close()
+
+	id := resp.Header.Get("hue-bridgeid")
+	if id == "" && !strings.Contains(resp.Header.Get("Server"), "IpBridge") {
+		return Bridge{}, false
+	}
+
+	b := Bridge{ID: normalizeBridgeID(id), Host: from.String()}
+	if loc, err := url.Parse(resp.Header.Get("Location")); err == nil && loc.Hostname() != "" {
+		b.Host = loc.Hostname()
+		if port, err := strconv.Atoi(loc.Port()); err == nil {
+			b.Port = port
+		}
+	}
+
+	return b, true
+}
@@ -0,0 +1,322 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/net/dns/dnsmessage"
+)
+
+// startResponder listens on loopback and answers every packet with the result of respond
+func startResponder(t *testing.T, respond func(query []byte) [][]byte) (addr string, teardown func()) {
+	conn, err := net.ListenUDP("udp4", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		t.Fatalf("could not listen: %v", err)
+	}
+
+	go func() {
+		buf := make([]byte, 65536)
+		for {
+			n, from, err := conn.ReadFromUDP(buf)
+			if err != nil {
+				return
+			}
+			for _, msg := range respond(buf[:n]) {
+				conn.WriteToUDP(msg, from)
+			}
+		}
+	}()
+
+	return conn.LocalAddr().String(), func() { conn.Close() }
+}
+
+func mdnsAnswer(t *testing.T, instance, bridgeID string, ip [4]byte) []byte {
+	b := dnsmessage.NewBuilder(nil, dnsmessage.Header{Response: true, Authoritative: true})
+	b.EnableCompression()
+	b.StartAnswers()
+
+	instanceName := dnsmessage.MustNewName(instance + "." + mdnsService)
+	hostName := dnsmessage.MustNewName(instance + ".local.")
+	hdr := func(name dnsmessage.Name) dnsmessage.ResourceHeader {
+		return dnsmessage.ResourceHeader{Name: name, Class: dnsmessage.ClassINET, TTL: 120}
+	}
+
+	b.PTRResource(hdr(dnsmessage.MustNewName(mdnsService)), dnsmessage.PTRResource{PTR: instanceName})
+	b.StartAdditionals()
+	b.SRVResource(hdr(instanceName), dnsmessage.SRVResource{Target: hostName, Port: 443})
+	b.TXTResource(hdr(instanceName), dnsmessage.TXTResource{TXT: []string{"bridgeid=" + bridgeID, "modelid=BSB002"}})
+	b.AResource(hdr(hostName), dnsmessage.AResource{A: ip})
+
+	msg, err := b.Finish()
+	if err != nil {
+		t.Fatalf("could not build mdns answer: %v", err)
+	}
+	return msg
+}
+
+func TestMDNSDiscoverer_Discover(t *testing.T) {
+	addr, teardown := startResponder(t, func(query []byte) [][]byte {
+		var p dnsmessage.Parser
+		p.Start(query)
+		q, err := p.Question()
+		if err != nil || q.Name.String() != mdnsService || q.Type != dnsmessage.TypePTR {
+			t.Errorf("unexpected query %+v", q)
+			return nil
+		}
+		return [][]byte{
+			mdnsAnswer(t, "Philips Hue - 23BFC2", "001788fffe23bfc2", [4]byte{192, 168, 1, 10}),
+			mdnsAnswer(t, "Philips Hue - 09C4A1", "001788fffe09c4a1", [4]byte{192, 168, 1, 11}),
+		}
+	})
+	defer teardown()
+
+	d := &MDNSDiscoverer{Addr: addr, Timeout: 500 * time.Millisecond}
+	got, err := d.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("MDNSDiscoverer.Discover returned error: %+v", err)
+	}
+
+	assert.ElementsMatch(t, []Bridge{
+		{ID: "001788FFFE23BFC2", Host: "192.168.1.10", Port: 443, Model: "BSB002"},
+		{ID: "001788FFFE09C4A1", Host: "192.168.1.11", Port: 443, Model: "BSB002"},
+	}, got)
+}
+
+func TestDiscoverFirst(t *testing.T) {
+	addr, teardown := startResponder(t, func(query []byte) [][]byte {
+		return [][]byte{mdnsAnswer(t, "Philips Hue - 23BFC2", "001788fffe23bfc2", [4]byte{192, 168, 1, 10})}
+	})
+	defer teardown()
+
+	notCalled := discovererFunc(func(ctx context.Context) ([]Bridge, error) {
+		t.Error("Discoverer after the one which found a bridge was called")
+		return nil, nil
+	})
+
+	// Returns with the first answer instead of waiting for the timeout
+	start := time.Now()
+	got, err := discoverFirst(context.Background(), &MDNSDiscoverer{Addr: addr, Timeout: 5 * time.Second}, notCalled)
+	if err != nil {
+		t.Fatalf("discoverFirst returned error: %+v", err)
+	}
+	assert.True(t, time.Since(start) < time.Second)
+	assert.Equal(t, &Bridge{ID: "001788FFFE23BFC2", Host: "192.168.1.10", Port: 443, Model: "BSB002"}, got)
+
+	// DiscoverBridges waits for every bridge
+	start = time.Now()
+	bridges, err := DiscoverBridges(context.Background(), &MDNSDiscoverer{Addr: addr, Timeout: 300 * time.Millisecond})
+	assert.NoError(t, err)
+	assert.Len(t, bridges, 1)
+	assert.True(t, time.Since(start) >= 300*time.Millisecond)
+}
+
+func TestDiscoverFirst_Fallback(t *testing.T) {
+	failing := discovererFunc(func(ctx context.Context) ([]Bridge, error) {
+		return nil, errors.New("network is unreachable")
+	})
+	found := discovererFunc(func(ctx context.Context) ([]Bridge, error) {
+		return []Bridge{{ID: "A", Host: "192.168.1.10"}, {ID: "B", Host: "192.168.1.11"}}, nil
+	})
+
+	got, err := discoverFirst(context.Background(), failing, found)
+	assert.NoError(t, err)
+	assert.Equal(t, &Bridge{ID: "A", Host: "192.168.1.10"}, got)
+
+	_, err = discoverFirst(context.Background(), failing)
+	assert.True(t, errors.Is(err, ErrNoBridgeFound))
+	assert.Contains(t, err.Error(), "network is unreachable")
+}
+
+func TestSSDPDiscoverer_Discover(t *testing.T) {
+	addr, teardown := startResponder(t, func(query []byte) [][]byte {
+		assert.Contains(t, string(query), "M-SEARCH * HTTP/1.1")
+		return [][]byte{
+			[]byte("HTTP/1.1 200 OK\r\n" +
+				"CACHE-CONTROL: max-age=100\r\n" +
+				"LOCATION: http://192.168.1.10:80/description.xml\r\n" +
+				"SERVER: Linux/3.14.0 UPnP/1.0 IpBridge/1.41.0\r\n" +
+				"hue-bridgeid: 001788FFFE23BFC2\r\n" +
+				"ST: upnp:rootdevice\r\n" +
+				"USN: uuid:2f402f80-da50-11e1-9b23-00178823bfc2::upnp:rootdevice\r\n\r\n"),
+			// Same bridge answers for every search target
+			[]byte("HTTP/1.1 200 OK\r\n" +
+				"LOCATION: http://192.168.1.10:80/description.xml\r\n" +
+				"SERVER: Linux/3.14.0 UPnP/1.0 IpBridge/1.41.0\r\n" +
+				"hue-bridgeid: 001788FFFE23BFC2\r\n" +
+				"ST: urn:schemas-upnp-org:device:basic:1\r\n\r\n"),
+			// Not a bridge
+			[]byte("HTTP/1.1 200 OK\r\n" +
+				"LOCATION: http://192.168.1.20:1400/xml/device_description.xml\r\n" +
+				"SERVER: Linux UPnP/1.0 Sonos/57.3-79200\r\n" +
+				"ST: upnp:rootdevice\r\n\r\n"),
+		}
+	})
+	defer teardown()
+
+	d := &SSDPDiscoverer{Addr: addr, Timeout: 500 * time.Millisecond}
+	got, err := d.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("SSDPDiscoverer.Discover returned error: %+v", err)
+	}
+
+	assert.Equal(t, []Bridge{{ID: "001788FFFE23BFC2", Host: "192.168.1.10", Port: 80}}, got)
+}
+
+func TestCloudDiscoverer_Discover(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"id":"001788fffe23bfc2","internalipaddress":"192.168.1.10","port":443},{"id":"001788fffe09c4a1","internalipaddress":"192.168.1.11"}]`)
+	}))
+	defer server.Close()
+
+	d := &CloudDiscoverer{URL: server.URL}
+	got, err := d.Discover(context.Background())
+	if err != nil {
+		t.Fatalf("CloudDiscoverer.Discover returned error: %+v", err)
+	}
+
+	assert.Equal(t, []Bridge{
+		{ID: "001788FFFE23BFC2", Host: "192.168.1.10", Port: 443},
+		{ID: "001788FFFE09C4A1", Host: "192.168.1.11"},
+	}, got)
+}
+
+type discovererFunc func(ctx context.Context) ([]Bridge, error)
+
+func (f discovererFunc) Discover(ctx context.Context) ([]Bridge, error) { return f(ctx) }
+
+func TestDiscoverBridges_Fallback(t *testing.T) {
+	failing := discovererFunc(func(ctx context.Context) ([]Bridge, error) {
+		return nil, errors.New("network is unreachable")
+	})
+	empty := discovererFunc(func(ctx context.Context) ([]Bridge, error) {
+		return nil, nil
+	})
+	found := discovererFunc(func(ctx context.Context) ([]Bridge, error) {
+		return []Bridge{{ID: "A", Host: "192.168.1.10"}, {ID: "A", Host: "192.168.1.10"}, {ID: "B", Host: "192.168.1.11"}}, nil
+	})
+
+	got, err := DiscoverBridges(context.Background(), failing, empty, found)
+	if err != nil {
+		t.Fatalf("DiscoverBridges returned error: %+v", err)
+	}
+	assert.Equal(t, []Bridge{{ID: "A", Host: "192.168.1.10"}, {ID: "B", Host: "192.168.1.11"}}, got)
+
+	_, err = DiscoverBridges(context.Background(), failing, empty)
+	assert.True(t, errors.Is(err, ErrNoBridgeFound))
+}
+
+func TestBridge_Addr(t *testing.T) {
+	assert.Equal(t, "192.168.1.10", Bridge{Host: "192.168.1.10", Port: 443}.Addr())
+	assert.Equal(t, "192.168.1.10", Bridge{Host: "192.168.1.10"}.Addr())
+	assert.Equal(t, "127.0.0.1:8080", Bridge{Host: "127.0.0.1", Port: 8080}.Addr())
+}
+
+func TestDiscoverAll(t *testing.T) {
+	bytes, _ := ioutil.ReadFile("testdata/Bridge_PublicConfig.json")
+	bridge := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Path != "/api/0/config" {
+			t.Errorf("Request path: %v, want /api/0/config", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	}))
+	defer bridge.Close()
+
+	// Another device which answered to discovery
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		fmt.Fprint(w, "<html></html>")
+	}))
+	defer other.Close()
+
+	candidate := func(serverURL string) Bridge {
+		u, _ := url.Parse(serverURL)
+		host, port, _ := net.SplitHostPort(u.Host)
+		b := Bridge{Host: host}
+		fmt.Sscan(port, &b.Port)
+		return b
+	}
+	discoverer := discovererFunc(func(ctx context.Context) ([]Bridge, error) {
+		return []Bridge{candidate(other.URL), candidate(bridge.URL)}, nil
+	})
+
+	got, err := DiscoverAll(context.Background(), discoverer)
+	if err != nil {
+		t.Fatalf("DiscoverAll returned error: %+v", err)
+	}
+
+	want := candidate(bridge.URL)
+	want.ID = "001788FFFE23BFC2"
+	want.Model = "BSB002"
+	want.Name = "Philips hue"
+	want.APIVersion = "1.35.0"
+	want.SWVersion = "1935144040"
+	want.MAC = "00:17:88:23:bf:c2"
+	assert.Equal(t, []Bridge{want}, got)
+
+	found, ok := FindBridge(got, "001788fffe23bfc2")
+	assert.True(t, ok)
+	assert.Equal(t, want, found)
+
+	_, ok = FindBridge(got, "001788FFFE09C4A1")
+	assert.False(t, ok)
+}
+
+func TestProbeBridge(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"status": "ok"}`)
+	}))
+	defer other.Close()
+
+	u, _ := url.Parse(other.URL)
+	_, err := ProbeBridge(context.Background(), u.Host)
+	assert.Error(t, err)
+}
+
+func TestDiscoverAll_NoBridge(t *testing.T) {
+	defer func(timeout time.Duration) { probeTimeout = timeout }(probeTimeout)
+	probeTimeout = 50 * time.Millisecond
+
+	// A device which never answers
+	release := make(chan struct{})
+	hanging := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer hanging.Close()
+	defer close(release)
+
+	other := httptest.NewServer(http.NotFoundHandler())
+	defer other.Close()
+
+	candidate := func(serverURL string) Bridge {
+		u, _ := url.Parse(serverURL)
+		host, port, _ := net.SplitHostPort(u.Host)
+		b := Bridge{Host: host}
+		fmt.Sscan(port, &b.Port)
+		return b
+	}
+	discoverer := discovererFunc(func(ctx context.Context) ([]Bridge, error) {
+		return []Bridge{candidate(hanging.URL), candidate(other.URL)}, nil
+	})
+
+	start := time.Now()
+	_, err := DiscoverAll(context.Background(), discoverer)
+	assert.True(t, time.Since(start) < time.Second)
+
+	assert.True(t, errors.Is(err, ErrNoBridgeFound))
+	assert.Contains(t, err.Error(), candidate(hanging.URL).Addr()+" didn't answer")
+	assert.Contains(t, err.Error(), candidate(other.URL).Addr()+" returned 404 Not Found")
+}
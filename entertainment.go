@@ -0,0 +1,187 @@
+package hue
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pion/dtls/v2"
+)
+
+const (
+	defaultStreamPort    = 2100
+	defaultStreamRate    = 50
+	defaultStreamTimeout = 10 * time.Second
+)
+
+// EntertainmentService has functions to stream colors to the lights of an Entertainment group.
+// Create the group with GroupService.CreateEntertainment.
+type EntertainmentService service
+
+type streamRequest struct {
+	Stream struct {
+		Active bool `json:"active"`
+	} `json:"stream"`
+}
+
+type locationsRequest struct {
+	Locations map[string][]float64 `json:"locations"`
+}
+
+// SetLocations changes the position of the lights in the group, each value is between -1 and 1 as x, y, z
+func (s *EntertainmentService) SetLocations(ctx context.Context, groupID string, locations map[string][]float64) (*Response, error) {
+	req, err := s.client.newRequest(http.MethodPut, s.client.path(groupServiceName, groupID), &locationsRequest{Locations: locations})
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Activate claims the group for streaming, the bridge rejects it if another user is streaming
+func (s *EntertainmentService) Activate(ctx context.Context, groupID string) (*Response, error) {
+	return s.setActive(ctx, groupID, true)
+}
+
+// Deactivate ends streaming to the group
+func (s *EntertainmentService) Deactivate(ctx context.Context, groupID string) (*Response, error) {
+	return s.setActive(ctx, groupID, false)
+}
+
+func (s *EntertainmentService) setActive(ctx context.Context, groupID string, active bool) (*Response, error) {
+	payload := &streamRequest{}
+	payload.Stream.Active = active
+	req, err := s.client.newRequest(http.MethodPut, s.client.path(groupServiceName, groupID), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Stream activates the group and opens a DTLS session to the bridge, clientKey is the hex key returned on pairing.
+// With StreamVersion2 the entertainment configuration must be started through the v2 API first and groupID is not used.
+// The bridge ends the session when no frame was sent for 10 seconds.
+func (s *EntertainmentService) Stream(ctx context.Context, groupID, clientKey string, opts *StreamOptions) (*Stream, error) {
+	key, err := hex.DecodeString(clientKey)
+	if err != nil {
+		return nil, fmt.Errorf("hue: clientkey must be hex encoded: %w", err)
+	}
+
+	o := StreamOptions{}
+	if opts != nil {
+		o = *opts
+	}
+	if o.Version == 0 {
+		o.Version = StreamVersion1
+	}
+	if o.Rate <= 0 || o.Rate > defaultStreamRate {
+		o.Rate = defaultStreamRate
+	}
+	if o.Port == 0 {
+		o.Port = defaultStreamPort
+	}
+	if o.Timeout == 0 {
+		o.Timeout = defaultStreamTimeout
+	}
+	if o.Version == StreamVersion2 && len(o.ConfigID) != configIDLength {
+		return nil, errors.New("hue: StreamVersion2 requires the id of the entertainment configuration")
+	}
+
+	addr, err := net.ResolveUDPAddr("udp", net.JoinHostPort(s.client.baseURL.Hostname(), strconv.Itoa(o.Port)))
+	if err != nil {
+		return nil, err
+	}
+
+	if o.Version == StreamVersion1 {
+		if _, err := s.Activate(ctx, groupID); err != nil {
+			return nil, err
+		}
+	}
+
+	dialCtx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+	conn, err := dtls.DialWithContext(dialCtx, "udp", addr, &dtls.Config{
+		PSK:             func([]byte) ([]byte, error) { return key, nil },
+		PSKIdentityHint: []byte(s.client.clientId),
+		CipherSuites:    []dtls.CipherSuiteID{dtls.TLS_PSK_WITH_AES_128_GCM_SHA256},
+	})
+	if err != nil {
+		if o.Version == StreamVersion1 {
+			s.Deactivate(ctx, groupID)
+		}
+		return nil, err
+	}
+
+	return &Stream{
+		conn:     conn,
+		service:  s,
+		groupID:  groupID,
+		opts:     o,
+		interval: time.Second / time.Duration(o.Rate),
+	}, nil
+}
+
+// Stream is a streaming session to an Entertainment group, it is safe for concurrent use
+type Stream struct {
+	conn     net.Conn
+	service  *EntertainmentService
+	groupID  string
+	opts     StreamOptions
+	interval time.Duration
+
+	mu   sync.Mutex
+	seq  uint8
+	last time.Time
+}
+
+// Send sends a frame with the colors of the channels in the color space of the options.
+// It waits if the previous frame was sent less than 1/Rate seconds ago.
+func (s *Stream) Send(channels ...StreamChannel) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	frame, err := encodeFrame(s.opts.Version, s.seq, s.opts.ColorSpace, s.opts.ConfigID, channels)
+	if err != nil {
+		return err
+	}
+
+	if wait := s.interval - time.Since(s.last); wait > 0 {
+		time.Sleep(wait)
+	}
+
+	if _, err := s.conn.Write(frame); err != nil {
+		return err
+	}
+	s.last = time.Now()
+	s.seq++
+
+	return nil
+}
+
+// Close ends the DTLS session and deactivates the group
+func (s *Stream) Close() error {
+	err := s.conn.Close()
+	if s.opts.Version == StreamVersion1 {
+		if _, dErr := s.service.Deactivate(context.Background(), s.groupID); err == nil {
+			err = dErr
+		}
+	}
+	return err
+}
@@ -0,0 +1,62 @@
+package hue
+
+import "time"
+
+// Classes of an Entertainment group
+const (
+	EntertainmentClassTV     = "TV"
+	EntertainmentClassFree   = "Free"
+	EntertainmentClassScreen = "Screen"
+)
+
+// StreamVersion is the version of the HueStream protocol
+type StreamVersion byte
+
+const (
+	StreamVersion1 StreamVersion = 1 // Addresses lights of an Entertainment group by their ids
+	StreamVersion2 StreamVersion = 2 // Addresses channels of an entertainment configuration of the v2 API
+)
+
+// ColorSpace of the values in a HueStream frame
+type ColorSpace byte
+
+const (
+	ColorSpaceRGB ColorSpace = 0x00
+	ColorSpaceXY  ColorSpace = 0x01 // x, y and brightness
+)
+
+// StreamOptions configures a streaming session, zero fields use the defaults
+type StreamOptions struct {
+	Version    StreamVersion // StreamVersion1 by default
+	ConfigID   string        // Id of the entertainment configuration, required for StreamVersion2
+	ColorSpace ColorSpace    // ColorSpaceRGB by default
+	Rate       int           // Frames per second, at most 50 which is the default
+	Port       int           // UDP port of the bridge, 2100 by default
+	Timeout    time.Duration // Timeout of the DTLS handshake, 10 seconds by default
+}
+
+// StreamChannel is the color of a light or channel in a frame
+type StreamChannel struct {
+	ID     uint16    // Light id with StreamVersion1, channel id with StreamVersion2
+	Values [3]uint16 // Red, green and blue or x, y and brightness, scaled to 0-65535
+}
+
+// RGBChannel returns the channel with red, green and blue between 0 and 1, for ColorSpaceRGB
+func RGBChannel(id uint16, r, g, b float64) StreamChannel {
+	return StreamChannel{ID: id, Values: [3]uint16{scale16(r), scale16(g), scale16(b)}}
+}
+
+// XYChannel returns the channel with x, y and brightness between 0 and 1, for ColorSpaceXY
+func XYChannel(id uint16, x, y, bri float64) StreamChannel {
+	return StreamChannel{ID: id, Values: [3]uint16{scale16(x), scale16(y), scale16(bri)}}
+}
+
+func scale16(v float64) uint16 {
+	switch {
+	case v <= 0:
+		return 0
+	case v >= 1:
+		return 0xffff
+	}
+	return uint16(v*0xffff + 0.5)
+}
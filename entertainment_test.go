@@ -0,0 +1,193 @@
+package hue
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pion/dtls/v2"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	testEntertainmentGroupId = "5"
+	testClientKey            = "321C0C2EBFA7361EDC8D3F4B3D5BFE2D"
+)
+
+// startDTLSServer listens like the bridge does for entertainment streaming.
+// It accepts one session authenticated with identity and key and sends the received frames to the channel.
+func startDTLSServer(t *testing.T, identity, clientKey string) (int, <-chan []byte, func()) {
+	key, _ := hex.DecodeString(clientKey)
+	listener, err := dtls.Listen("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)}, &dtls.Config{
+		PSK: func(hint []byte) ([]byte, error) {
+			if string(hint) != identity {
+				return nil, fmt.Errorf("unknown identity %q", hint)
+			}
+			return key, nil
+		},
+		PSKIdentityHint: []byte("go-hue"),
+		CipherSuites:    []dtls.CipherSuiteID{dtls.TLS_PSK_WITH_AES_128_GCM_SHA256},
+	})
+	if err != nil {
+		t.Fatalf("Couldn't start DTLS server: %v", err)
+	}
+
+	frames := make(chan []byte, 16)
+	go func() {
+		defer close(frames)
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		buf := make([]byte, 1024)
+		for {
+			n, err := conn.Read(buf)
+			if err != nil {
+				return
+			}
+			frames <- append([]byte(nil), buf[:n]...)
+		}
+	}()
+
+	return listener.Addr().(*net.UDPAddr).Port, frames, func() { listener.Close() }
+}
+
+// handleStreamActive serves the stream attribute of the group and records its changes
+func handleStreamActive(t *testing.T, mux *http.ServeMux) *[]bool {
+	var mu sync.Mutex
+	var changes []bool
+	activate, _ := ioutil.ReadFile("testdata/Entertainment_Activate.json")
+	deactivate, _ := ioutil.ReadFile("testdata/Entertainment_Deactivate.json")
+	mux.HandleFunc(fmt.Sprintf("/username/groups/%s", testEntertainmentGroupId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload streamRequest
+		getPayload(t, r, &payload)
+
+		mu.Lock()
+		changes = append(changes, payload.Stream.Active)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "application/json")
+		if payload.Stream.Active {
+			fmt.Fprint(w, string(activate))
+		} else {
+			fmt.Fprint(w, string(deactivate))
+		}
+	})
+	return &changes
+}
+
+func TestEntertainmentService_Stream(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	changes := handleStreamActive(t, mux)
+	port, frames, stop := startDTLSServer(t, "username", testClientKey)
+	defer stop()
+
+	ctx := context.Background()
+	stream, err := client.Entertainment.Stream(ctx, testEntertainmentGroupId, testClientKey, &StreamOptions{Port: port, Rate: 25})
+	if err != nil {
+		t.Fatalf("Entertainment.Stream returned error: %+v", err)
+	}
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		err := stream.Send(RGBChannel(1, 1, 0, 0), RGBChannel(2, 0, 0, 1))
+		assert.NoError(t, err)
+	}
+	// Three frames at 25 Hz take at least two intervals
+	assert.True(t, time.Since(start) >= 80*time.Millisecond)
+
+	for seq := 0; seq < 3; seq++ {
+		select {
+		case frame := <-frames:
+			want, _ := encodeFrame(StreamVersion1, uint8(seq), ColorSpaceRGB, "", []StreamChannel{RGBChannel(1, 1, 0, 0), RGBChannel(2, 0, 0, 1)})
+			assert.True(t, bytes.Equal(want, frame), "frame %d is %x, want %x", seq, frame, want)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("Frame %d wasn't received", seq)
+		}
+	}
+
+	assert.NoError(t, stream.Close())
+	assert.Equal(t, []bool{true, false}, *changes)
+}
+
+func TestEntertainmentService_Stream_WrongKey(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	changes := handleStreamActive(t, mux)
+	port, _, stop := startDTLSServer(t, "username", testClientKey)
+	defer stop()
+
+	ctx := context.Background()
+	_, err := client.Entertainment.Stream(ctx, testEntertainmentGroupId, "00112233445566778899AABBCCDDEEFF", &StreamOptions{Port: port, Timeout: time.Second})
+	assert.Error(t, err)
+
+	// The group is released again when the handshake fails
+	assert.Equal(t, []bool{true, false}, *changes)
+}
+
+func TestEntertainmentService_Stream_InvalidKey(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	ctx := context.Background()
+	_, err := client.Entertainment.Stream(ctx, testEntertainmentGroupId, "not hex", nil)
+	assert.Error(t, err)
+}
+
+func TestEntertainmentService_Activate_StreamingActive(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Entertainment_StreamingActive.json")
+	mux.HandleFunc(fmt.Sprintf("/username/groups/%s", testEntertainmentGroupId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, err := client.Entertainment.Activate(ctx, testEntertainmentGroupId)
+	assert.True(t, errors.Is(err, ErrStreamingNotAllowed))
+}
+
+func TestEntertainmentService_SetLocations(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Entertainment_SetLocations.json")
+	mux.HandleFunc(fmt.Sprintf("/username/groups/%s", testEntertainmentGroupId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload locationsRequest
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, []float64{-0.5, 0.5, 0}, payload.Locations["1"])
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, err := client.Entertainment.SetLocations(ctx, testEntertainmentGroupId, map[string][]float64{
+		"1": {-0.5, 0.5, 0},
+		"2": {0.5, 0.5, 0},
+	})
+	if err != nil {
+		t.Errorf("Entertainment.SetLocations returned error: %+v", err)
+	}
+}
@@ -0,0 +1,187 @@
+package hue
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Error codes documented by the bridge
+// https://developers.meethue.com/develop/hue-api/error-messages/
+const (
+	ErrorTypeUnauthorizedUser         = 1
+	ErrorTypeInvalidJSON              = 2
+	ErrorTypeResourceNotAvailable     = 3
+	ErrorTypeMethodNotAvailable       = 4
+	ErrorTypeMissingParameters        = 5
+	ErrorTypeParameterNotAvailable    = 6
+	ErrorTypeInvalidValue             = 7
+	ErrorTypeParameterNotModifiable   = 8
+	ErrorTypeTooManyItems             = 11
+	ErrorTypePortalConnectionRequired = 12
+	ErrorTypeLinkButtonNotPressed     = 101
+	ErrorTypeDHCPCannotBeDisabled     = 110
+	ErrorTypeInvalidUpdateState       = 111
+	ErrorTypeDeviceOff                = 201
+	ErrorTypeGroupTableFull           = 301
+	ErrorTypeLightGroupTableFull      = 302
+	ErrorTypeStreamingNotAllowed      = 307
+	ErrorTypeStreamingActive          = 308
+	ErrorTypeSceneBufferFull          = 402
+	ErrorTypeSceneCreationInProgress  = 403
+	ErrorTypeSensorListFull           = 501
+	ErrorTypeScheduleListFull         = 601
+	ErrorTypeScheduleTimezoneNotValid = 602
+	ErrorTypeRuleEngineFull           = 701
+	ErrorTypeConditionError           = 702
+	ErrorTypeActionError              = 703
+	ErrorTypeUnableToConnect          = 704
+	ErrorTypeInternalError            = 901
+)
+
+// Sentinel errors which can be used with errors.Is
+//
+//	if errors.Is(err, hue.ErrUnauthorizedUser) { ... }
+var (
+	ErrUnauthorizedUser         = &Error{Type: ErrorTypeUnauthorizedUser, Description: "unauthorized user"}
+	ErrInvalidJSON              = &Error{Type: ErrorTypeInvalidJSON, Description: "body contains invalid JSON"}
+	ErrResourceNotAvailable     = &Error{Type: ErrorTypeResourceNotAvailable, Description: "resource not available"}
+	ErrMethodNotAvailable       = &Error{Type: ErrorTypeMethodNotAvailable, Description: "method not available for resource"}
+	ErrMissingParameters        = &Error{Type: ErrorTypeMissingParameters, Description: "missing parameters in body"}
+	ErrParameterNotAvailable    = &Error{Type: ErrorTypeParameterNotAvailable, Description: "parameter not available"}
+	ErrInvalidValue             = &Error{Type: ErrorTypeInvalidValue, Description: "invalid value for parameter"}
+	ErrParameterNotModifiable   = &Error{Type: ErrorTypeParameterNotModifiable, Description: "parameter is not modifiable"}
+	ErrTooManyItems             = &Error{Type: ErrorTypeTooManyItems, Description: "too many items in list"}
+	ErrPortalConnectionRequired = &Error{Type: ErrorTypePortalConnectionRequired, Description: "portal connection required"}
+	ErrLinkButtonNotPressed     = &Error{Type: ErrorTypeLinkButtonNotPressed, Description: "link button not pressed"}
+	ErrDHCPCannotBeDisabled     = &Error{Type: ErrorTypeDHCPCannotBeDisabled, Description: "DHCP cannot be disabled"}
+	ErrInvalidUpdateState       = &Error{Type: ErrorTypeInvalidUpdateState, Description: "invalid updatestate"}
+	ErrDeviceOff                = &Error{Type: ErrorTypeDeviceOff, Description: "device is set to off"}
+	ErrGroupTableFull           = &Error{Type: ErrorTypeGroupTableFull, Description: "group could not be created, group table full"}
+	ErrLightGroupTableFull      = &Error{Type: ErrorTypeLightGroupTableFull, Description: "light could not be added to group, group table full"}
+	ErrSceneBufferFull          = &Error{Type: ErrorTypeSceneBufferFull, Description: "scene could not be created, buffer full"}
+	ErrSceneCreationInProgress  = &Error{Type: ErrorTypeSceneCreationInProgress, Description: "scene could not be created, creation in progress"}
+	ErrSensorListFull           = &Error{Type: ErrorTypeSensorListFull, Description: "sensor list is full"}
+	ErrScheduleListFull         = &Error{Type: ErrorTypeScheduleListFull, Description: "schedule list is full"}
+	ErrScheduleTimezoneNotValid = &Error{Type: ErrorTypeScheduleTimezoneNotValid, Description: "schedule time-zone not valid"}
+	ErrRuleEngineFull           = &Error{Type: ErrorTypeRuleEngineFull, Description: "rule engine full"}
+	ErrConditionError           = &Error{Type: ErrorTypeConditionError, Description: "condition error"}
+	ErrActionError              = &Error{Type: ErrorTypeActionError, Description: "action error"}
+	ErrUnableToConnect          = &Error{Type: ErrorTypeUnableToConnect, Description: "unable to connect"}
+	ErrStreamingNotAllowed      = &Error{Type: ErrorTypeStreamingNotAllowed, Description: "cannot claim stream ownership"}
+	ErrStreamingActive          = &Error{Type: ErrorTypeStreamingActive, Description: "streaming is active"}
+	ErrInternalError            = &Error{Type: ErrorTypeInternalError, Description: "internal error"}
+)
+
+// HTTPError is returned when the server answers with an error status and without errors of the bridge
+type HTTPError struct {
+	StatusCode int    // e.g. 401
+	Status     string // e.g. 401 Unauthorized
+	Body       string
+}
+
+func (e *HTTPError) Error() string {
+	return fmt.Sprintf("hue: server returned %v", e.Status)
+}
+
+// ErrInvalidResponse is returned when the bridge answers with an unexpected payload
+var ErrInvalidResponse = errors.New("hue: the bridge didn't return valid response")
+
+// Error is a single error returned by the bridge for a request
+type Error struct {
+	Type        int    // Error code documented by Hue
+	Address     string // The resource or parameter the error relates to
+	Description string // Human readable description of the error
+}
+
+func newError(e *ApiError) *Error {
+	return &Error{Type: e.Type, Address: e.Address, Description: e.Description}
+}
+
+func (e *Error) Error() string {
+	if e.Address == "" {
+		return fmt.Sprintf("hue: %v (type %d)", e.Description, e.Type)
+	}
+	return fmt.Sprintf("hue: %v (type %d, address %v)", e.Description, e.Type, e.Address)
+}
+
+// Is reports whether target is an *Error with the same type.
+// If target has an address, it must match as well.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	if !ok {
+		return false
+	}
+	return t.Type == e.Type && (t.Address == "" || t.Address == e.Address)
+}
+
+// MultiError holds every error returned by the bridge for a single request.
+// The bridge answers each attribute of a request separately, so one request may fail partially.
+type MultiError []*Error
+
+func (m MultiError) Error() string {
+	msgs := make([]string, 0, len(m))
+	for _, e := range m {
+		msgs = append(msgs, e.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// Is reports whether any of the errors matches target
+func (m MultiError) Is(target error) bool {
+	for _, e := range m {
+		if e.Is(target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As sets target to the first error when target is **Error
+func (m MultiError) As(target interface{}) bool {
+	if t, ok := target.(**Error); ok && len(m) > 0 {
+		*t = m[0]
+		return true
+	}
+	return false
+}
+
+// errorOrNil returns nil if there is no error, the error itself if there is one
+func (m MultiError) errorOrNil() error {
+	switch len(m) {
+	case 0:
+		return nil
+	case 1:
+		return m[0]
+	default:
+		return m
+	}
+}
+
+// apiErrors returns the errors in body when it is a list of ApiResponse.
+// It returns nil for any other payload.
+// A single error is returned as *Error, several as MultiError.
+func apiErrors(body []byte) error {
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || body[0] != '[' {
+		return nil
+	}
+
+	var responses []struct {
+		Error *ApiError `json:"error,omitempty"`
+	}
+	if err := json.Unmarshal(body, &responses); err != nil {
+		return nil
+	}
+
+	var errs MultiError
+	for _, r := range responses {
+		if r.Error != nil {
+			errs = append(errs, newError(r.Error))
+		}
+	}
+
+	return errs.errorOrNil()
+}
@@ -0,0 +1,81 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestError_Is(t *testing.T) {
+	err := &Error{Type: ErrorTypeInvalidValue, Address: "/lights/1/state/hue", Description: "invalid value"}
+
+	assert.True(t, errors.Is(err, ErrInvalidValue))
+	assert.True(t, errors.Is(err, &Error{Type: ErrorTypeInvalidValue, Address: "/lights/1/state/hue"}))
+	assert.False(t, errors.Is(err, &Error{Type: ErrorTypeInvalidValue, Address: "/lights/1/state/bri"}))
+	assert.False(t, errors.Is(err, ErrDeviceOff))
+	assert.False(t, errors.Is(fmt.Errorf("wrapped: %w", err), ErrDeviceOff))
+	assert.True(t, errors.Is(fmt.Errorf("wrapped: %w", err), ErrInvalidValue))
+}
+
+func TestMultiError(t *testing.T) {
+	err := MultiError{
+		{Type: ErrorTypeDeviceOff, Address: "/lights/1/state/bri", Description: "device is set to off"},
+		{Type: ErrorTypeInvalidValue, Address: "/lights/1/state/hue", Description: "invalid value"},
+	}
+
+	assert.True(t, errors.Is(err, ErrDeviceOff))
+	assert.True(t, errors.Is(err, ErrInvalidValue))
+	assert.False(t, errors.Is(err, ErrUnauthorizedUser))
+
+	var hueErr *Error
+	assert.True(t, errors.As(err, &hueErr))
+	assert.Equal(t, ErrorTypeDeviceOff, hueErr.Type)
+
+	assert.Equal(t, "hue: device is set to off (type 201, address /lights/1/state/bri); hue: invalid value (type 7, address /lights/1/state/hue)", err.Error())
+}
+
+func TestApiErrors(t *testing.T) {
+	tests := []struct {
+		body string
+		want error
+	}{
+		{body: ``, want: nil},
+		{body: `{"name": "light"}`, want: nil},
+		{body: `[{"success":{"/lights/1/state/on":true}}]`, want: nil},
+		{body: `[{"success":"/groups/1 deleted."}]`, want: nil},
+		{
+			body: `[{"error":{"type":1,"address":"/","description":"unauthorized user"}}]`,
+			want: &Error{Type: 1, Address: "/", Description: "unauthorized user"},
+		},
+		{
+			body: `[{"error":{"type":7,"address":"/a","description":"a"}},{"error":{"type":6,"address":"/b","description":"b"}}]`,
+			want: MultiError{{Type: 7, Address: "/a", Description: "a"}, {Type: 6, Address: "/b", Description: "b"}},
+		},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, apiErrors([]byte(tt.body)), tt.body)
+	}
+}
+
+func TestClient_UnauthorizedUser(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_Unauthorized.json")
+	mux.HandleFunc("/username/lights", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	_, _, err := client.Lights.GetAll(context.Background())
+	if !errors.Is(err, ErrUnauthorizedUser) {
+		t.Errorf("Lights.GetAll returned error %v, want %v", err, ErrUnauthorizedUser)
+	}
+}
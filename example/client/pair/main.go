@@ -0,0 +1,52 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/firstthumb/go-hue"
+)
+
+var (
+	deviceType = flag.String("deviceType", "go-hue#example", "Name of the user on the bridge.")
+)
+
+func main() {
+	flag.Parse()
+
+	bridges, err := hue.DiscoverAll(context.Background())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not find the bridge")
+		os.Exit(1)
+	}
+
+	path, err := hue.DefaultCredentialsPath()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not find the config directory", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	// The user is saved, the other examples find it by the bridge id
+	creds, err := hue.Pair(ctx, bridges[0].Addr(), *deviceType, &hue.PairOptions{
+		ClientOptions: &hue.ClientOptions{
+			CredentialStore: hue.NewFileCredentialStore(path),
+			BridgeID:        bridges[0].ID,
+		},
+		OnProgress: func(p hue.PairingProgress) {
+			fmt.Printf("\rPress the link button on the bridge, %2.0fs left", p.Remaining.Seconds())
+		},
+	})
+	fmt.Println()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "could not pair with the bridge", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Paired as %v, saved to %v\n", creds.Username, path)
+}
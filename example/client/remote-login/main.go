@@ -3,25 +3,48 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"flag"
 	"fmt"
+	"os"
 
 	"github.com/firstthumb/go-hue"
 )
 
+var (
+	bridgeID = flag.String("bridgeId", "", "Bridge ID to keep the token and username for, e.g. 001788FFFE23BFC2")
+)
+
 func main() {
-	auth := hue.NewAuthenticator("http://localhost:8181/callback")
+	flag.Parse()
+
+	auth := hue.NewAuthenticatorWithOptions(&hue.AuthenticatorOptions{
+		AppID:        os.Getenv("HUE_APP_ID"),
+		ClientID:     os.Getenv("HUE_CLIENT_ID"),
+		ClientSecret: os.Getenv("HUE_CLIENT_SECRET"),
+		RedirectURL:  "http://localhost:8181/callback", // Same callback url defined in your app
+		PKCE:         true,
+	})
+	if *bridgeID != "" {
+		// Token and username are reused next time, refreshed tokens are saved
+		path, err := hue.DefaultCredentialsPath()
+		if err != nil {
+			panic(err)
+		}
+		auth.SetCredentialStore(hue.NewFileCredentialStore(path), *bridgeID)
+	}
+
 	client, err := auth.Authenticate()
 	if err != nil {
 		panic(err)
 	}
-	// Client is created but not authenticated by bridge
-	username, err := client.CreateRemoteUser()
-	if err != nil {
-		panic(err)
+
+	if client.GetClientID() == "" {
+		// Client is created but not authenticated by bridge, the new user is used afterwards
+		if _, err := client.CreateRemoteUser(); err != nil {
+			panic(err)
+		}
 	}
-	// Save "username" for next usage
-	// username := "S8uATAguQtowBJnTPpMr8q8nDkskQ6hHbdfAUn1C"
-	client.Login(username)
+
 	result, _, _ := client.Lights.GetAll(context.Background())
 	lights, _ := json.Marshal(result)
 	fmt.Println(string(lights))
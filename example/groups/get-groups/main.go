@@ -17,20 +17,25 @@ var (
 func main() {
 	flag.Parse()
 
-	if len(*clientID) == 0 {
-		fmt.Fprintln(os.Stderr, "Please specify a Client ID")
-		fmt.Println("Flags: ")
-		flag.PrintDefaults()
-		os.Exit(2)
-	}
-
-	host, err := hue.Discover()
+	bridges, err := hue.DiscoverAll(context.Background())
 	if err != nil {
 		fmt.Fprintln(os.Stderr, "could not find the bridge")
 		os.Exit(1)
 	}
 
-	client := hue.NewClient(host, *clientID, nil)
+	// Users paired by example/client/pair are stored, so -clientId is optional
+	opts := &hue.ClientOptions{BridgeID: bridges[0].ID}
+	if path, err := hue.DefaultCredentialsPath(); err == nil {
+		opts.CredentialStore = hue.NewFileCredentialStore(path)
+	}
+
+	client := hue.NewClient(bridges[0].Addr(), *clientID, opts)
+	if len(client.GetClientID()) == 0 {
+		fmt.Fprintln(os.Stderr, "Please specify a Client ID or pair with example/client/pair")
+		fmt.Println("Flags: ")
+		flag.PrintDefaults()
+		os.Exit(2)
+	}
 	result, _, _ := client.Groups.GetAll(context.Background())
 	groups, _ := json.Marshal(result)
 	fmt.Println(string(groups))
@@ -8,9 +8,12 @@ require (
 	github.com/google/go-cmp v0.5.5
 	github.com/lucasb-eyer/go-colorful v1.0.3
 	github.com/muesli/gamut v0.2.0
+	github.com/pion/dtls/v2 v2.2.12
 	github.com/pkg/browser v0.0.0-20210606212950-a7b7a6107d32
+	github.com/prometheus/client_golang v1.12.2
 	github.com/sirupsen/logrus v1.8.1
-	github.com/stretchr/testify v1.4.0
+	github.com/stretchr/testify v1.8.4 // required by pion/transport/v2, a dependency of pion/dtls/v2
 	github.com/thoas/go-funk v0.8.0
+	golang.org/x/net v0.20.0 // required by pion/dtls/v2
 	golang.org/x/oauth2 v0.0.0-20210514164344-f6687ab2804c
 )
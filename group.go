@@ -2,7 +2,6 @@ package hue
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"strconv"
 
@@ -16,6 +15,7 @@ const groupServiceName = "groups"
 
 const groupTypeLight = "LightGroup"
 const groupTypeRoom = "Room"
+const groupTypeEntertainment = "Entertainment"
 
 type createGroupRequest struct {
 	Lights []string `json:"lights,omitempty"`
@@ -50,6 +50,7 @@ func (s *GroupService) GetAll(ctx context.Context) ([]Group, *Response, error) {
 	for i, g := range groups {
 		id, _ := strconv.Atoi(i)
 		g.ID = id
+		groups[i] = g
 	}
 
 	return funk.Values(groups).([]Group), resp, nil
@@ -73,14 +74,17 @@ func (s *GroupService) CreateGroup(ctx context.Context, name string, lights []st
 		return "", resp, err
 	}
 
-	if len(apiResponses) == 0 || (apiResponses)[0].Error != nil {
-		return "", resp, errors.New((apiResponses)[0].Error.Description)
+	if len(apiResponses) == 0 {
+		return "", resp, ErrInvalidResponse
 	}
 
 	// Get first success message
-	successResponse := (apiResponses)[0].Success
+	id, ok := apiResponses[0].Success["id"].(string)
+	if !ok {
+		return "", resp, ErrInvalidResponse
+	}
 
-	return successResponse["id"].(string), resp, nil
+	return id, resp, nil
 }
 
 // CreateGroup creates light room and returns id of the room
@@ -102,14 +106,50 @@ func (s *GroupService) CreateRoom(ctx context.Context, name string, lights []str
 		return "", resp, err
 	}
 
-	if len(apiResponses) == 0 || (apiResponses)[0].Error != nil {
-		return "", resp, errors.New((apiResponses)[0].Error.Description)
+	if len(apiResponses) == 0 {
+		return "", resp, ErrInvalidResponse
 	}
 
 	// Get first success message
-	successResponse := (apiResponses)[0].Success
+	id, ok := apiResponses[0].Success["id"].(string)
+	if !ok {
+		return "", resp, ErrInvalidResponse
+	}
 
-	return successResponse["id"].(string), resp, nil
+	return id, resp, nil
+}
+
+// CreateEntertainment creates an Entertainment group which can stream colors to its lights and returns id of the group.
+// Class is one of EntertainmentClass*, only lights with Capabilities.Streaming.Renderer can be added.
+func (s *GroupService) CreateEntertainment(ctx context.Context, name, class string, lights []string) (string, *Response, error) {
+	payload := &createGroupRequest{
+		Name:   name,
+		Lights: lights,
+		Type:   groupTypeEntertainment,
+		Class:  String(class),
+	}
+	req, err := s.client.newRequest(http.MethodPost, s.groupServicePath(), payload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	if err != nil {
+		return "", resp, err
+	}
+
+	if len(apiResponses) == 0 {
+		return "", resp, ErrInvalidResponse
+	}
+
+	// Get first success message
+	id, ok := apiResponses[0].Success["id"].(string)
+	if !ok {
+		return "", resp, ErrInvalidResponse
+	}
+
+	return id, resp, nil
 }
 
 // Get returns the group by id
@@ -147,14 +187,7 @@ func (s *GroupService) Update(ctx context.Context, id string, name *string, ligh
 	}
 
 	if len(apiResponses) == 0 {
-		return false, resp, errors.New("the bridge didn't return valid response")
-	}
-
-	// If response has any error, return as fail
-	for _, r := range apiResponses {
-		if r.Error != nil {
-			return false, resp, errors.New(r.Error.Description)
-		}
+		return false, resp, ErrInvalidResponse
 	}
 
 	return true, resp, nil
@@ -169,11 +202,7 @@ func (s *GroupService) SetState(ctx context.Context, id string, payload SetState
 
 	var apiResponses []ApiResponse
 	resp, err := s.client.do(ctx, req, &apiResponses)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return apiResponses, resp, nil
+	return apiResponses, resp, err
 }
 
 // Delete removes the group
@@ -190,7 +219,7 @@ func (s *GroupService) Delete(ctx context.Context, id string) (*Response, error)
 	}
 
 	if len(apiResponses) == 0 {
-		return resp, errors.New("the bridge didn't return valid response")
+		return resp, ErrInvalidResponse
 	}
 
 	return resp, nil
@@ -1,6 +1,9 @@
 package hue
 
-import "context"
+import (
+	"context"
+	"net/http"
+)
 
 func (s *GroupService) TurnOn(ctx context.Context, id string) error {
 	_, _, err := s.SetState(ctx, id, SetStateParams{On: Bool(true)})
@@ -23,3 +26,8 @@ func (s *GroupService) TurnOffAll(ctx context.Context, ids ...string) {
 		s.SetState(ctx, id, SetStateParams{On: Bool(false)})
 	}
 }
+
+// StateCommand returns the command which sets the state of the group, e.g. to use in a schedule
+func (s *GroupService) StateCommand(id string, payload SetStateParams) *Command {
+	return &Command{Address: "/" + defaultBasePath + s.groupServicePath(id, "action"), Method: http.MethodPut, Body: payload}
+}
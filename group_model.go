@@ -9,6 +9,25 @@ type Group struct {
 	Lights []string    `json:"lights"` // The IDs of the lights that are in the group.
 	Type   string      `json:"type"`   // If not provided upon creation “LightGroup” is used. Can be “LightGroup”, “Room” or either “Luminaire” or “LightSource” if a Multisource Luminaire is present in the system.
 	Action GroupAction `json:"action"` // The light state of one of the lamps in the group.
+	State  GroupState  `json:"state"`  // Whether the lights of the group are on.
+
+	Class     string               `json:"class,omitempty"`     // Category of a Room, or TV, Free or Screen for an Entertainment group
+	Locations map[string][]float64 `json:"locations,omitempty"` // Entertainment only, position of each light from -1 to 1 as x, y, z
+	Stream    *GroupStream         `json:"stream,omitempty"`    // Entertainment only, state of the streaming session
+}
+
+// GroupStream is the streaming state of an Entertainment group
+type GroupStream struct {
+	ProxyMode string `json:"proxymode"` // auto or manual
+	ProxyNode string `json:"proxynode"` // Address of the light which forwards the stream, e.g. /lights/1
+	Active    bool   `json:"active"`
+	Owner     string `json:"owner,omitempty"` // Whitelist user that is streaming
+}
+
+// GroupState is reported by the bridge, it can't be set.
+type GroupState struct {
+	AllOn bool `json:"all_on"`
+	AnyOn bool `json:"any_on"`
 }
 
 // GroupAction is used to execute actions on all lights in a group.
@@ -12,6 +12,7 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
+	"github.com/stretchr/testify/assert"
 	funk "github.com/thoas/go-funk"
 )
 
@@ -46,6 +47,31 @@ func TestGroupService_GetAll(t *testing.T) {
 	}))
 }
 
+// GetAll used to return the groups without their ID, because it was set on a copy of each group
+func TestGroupService_GetAll_IDs(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Group_GetAll.json")
+	mux.HandleFunc("/username/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Groups.GetAll(ctx)
+	if err != nil {
+		t.Errorf("Group.GetAll returned error: %+v", err)
+	}
+
+	names := make(map[int]string)
+	for _, g := range got {
+		names[g.ID] = g.Name
+	}
+	assert.Equal(t, map[int]string{1: "Group 1", 2: "Group 2"}, names)
+}
+
 func TestGroupService_Get(t *testing.T) {
 	client, mux, _, teardown := setup()
 	defer teardown()
@@ -141,3 +167,34 @@ func TestGroupService_Delete(t *testing.T) {
 		t.Errorf("Group.Delete returned error: %+v", err)
 	}
 }
+
+func TestGroupService_CreateEntertainment(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Group_CreateEntertainment.json")
+	mux.HandleFunc("/username/groups", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, map[string]interface{}{
+			"name":   "TV area",
+			"type":   "Entertainment",
+			"class":  "TV",
+			"lights": []interface{}{"1", "2"},
+		}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Groups.CreateEntertainment(ctx, "TV area", EntertainmentClassTV, []string{"1", "2"})
+	if err != nil {
+		t.Errorf("Group.CreateEntertainment returned error: %+v", err)
+	}
+
+	assert.Equal(t, "5", got)
+}
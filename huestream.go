@@ -0,0 +1,71 @@
+package hue
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+)
+
+const (
+	huestreamProtocol = "HueStream"
+
+	// Most channels a frame may contain
+	maxStreamLightsV1   = 10
+	maxStreamChannelsV2 = 20
+
+	configIDLength = 36 // Entertainment configuration id as UUID string
+)
+
+// ErrTooManyChannels is returned when a frame has more channels than the protocol supports
+var ErrTooManyChannels = errors.New("hue: too many channels in stream frame")
+
+// encodeFrame returns a HueStream message, which is a 16 bytes header followed by the channels
+//
+//	v1: header | per light: type (0x00), light id (uint16), 3 values (uint16)
+//	v2: header | configuration id (36 bytes) | per channel: channel id (byte), 3 values (uint16)
+func encodeFrame(version StreamVersion, seq uint8, space ColorSpace, configID string, channels []StreamChannel) ([]byte, error) {
+	frame := make([]byte, 0, 16+configIDLength+9*len(channels))
+	frame = append(frame, huestreamProtocol...)
+	frame = append(frame, byte(version), 0x00, seq, 0x00, 0x00, byte(space), 0x00)
+
+	switch version {
+	case StreamVersion1:
+		if len(channels) > maxStreamLightsV1 {
+			return nil, ErrTooManyChannels
+		}
+		for _, c := range channels {
+			frame = append(frame, 0x00)
+			frame = appendUint16(frame, c.ID)
+			frame = appendValues(frame, c.Values)
+		}
+	case StreamVersion2:
+		if len(configID) != configIDLength {
+			return nil, fmt.Errorf("hue: entertainment configuration id must be %d characters, got %q", configIDLength, configID)
+		}
+		if len(channels) > maxStreamChannelsV2 {
+			return nil, ErrTooManyChannels
+		}
+		frame = append(frame, configID...)
+		for _, c := range channels {
+			frame = append(frame, byte(c.ID))
+			frame = appendValues(frame, c.Values)
+		}
+	default:
+		return nil, fmt.Errorf("hue: unsupported HueStream version %d", version)
+	}
+
+	return frame, nil
+}
+
+func appendUint16(b []byte, v uint16) []byte {
+	var buf [2]byte
+	binary.BigEndian.PutUint16(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func appendValues(b []byte, values [3]uint16) []byte {
+	for _, v := range values {
+		b = appendUint16(b, v)
+	}
+	return b
+}
@@ -0,0 +1,57 @@
+package hue
+
+import (
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEncodeFrame_V1(t *testing.T) {
+	got, err := encodeFrame(StreamVersion1, 7, ColorSpaceRGB, "", []StreamChannel{
+		RGBChannel(1, 1, 0, 0),
+		{ID: 0x0102, Values: [3]uint16{0x0a0b, 0x0c0d, 0x0e0f}},
+	})
+	assert.NoError(t, err)
+
+	want := hex.EncodeToString([]byte("HueStream")) +
+		"0100" + "07" + "0000" + "00" + "00" +
+		"00" + "0001" + "ffff" + "0000" + "0000" +
+		"00" + "0102" + "0a0b" + "0c0d" + "0e0f"
+	assert.Equal(t, want, hex.EncodeToString(got))
+}
+
+func TestEncodeFrame_V2(t *testing.T) {
+	configID := "1a8d99cc-967b-44f2-9202-43f976c0fa6b"
+	got, err := encodeFrame(StreamVersion2, 0, ColorSpaceXY, configID, []StreamChannel{
+		XYChannel(3, 0.5, 0.25, 1),
+	})
+	assert.NoError(t, err)
+
+	want := hex.EncodeToString([]byte("HueStream")) +
+		"0200" + "00" + "0000" + "01" + "00" +
+		hex.EncodeToString([]byte(configID)) +
+		"03" + "8000" + "4000" + "ffff"
+	assert.Equal(t, want, hex.EncodeToString(got))
+}
+
+func TestEncodeFrame_Errors(t *testing.T) {
+	_, err := encodeFrame(StreamVersion1, 0, ColorSpaceRGB, "", make([]StreamChannel, 11))
+	assert.Equal(t, ErrTooManyChannels, err)
+
+	_, err = encodeFrame(StreamVersion2, 0, ColorSpaceRGB, strings.Repeat("a", 36), make([]StreamChannel, 21))
+	assert.Equal(t, ErrTooManyChannels, err)
+
+	_, err = encodeFrame(StreamVersion2, 0, ColorSpaceRGB, "short", nil)
+	assert.Error(t, err)
+
+	_, err = encodeFrame(3, 0, ColorSpaceRGB, "", nil)
+	assert.Error(t, err)
+}
+
+func TestScale16(t *testing.T) {
+	assert.Equal(t, uint16(0), scale16(-0.5))
+	assert.Equal(t, uint16(0xffff), scale16(1.5))
+	assert.Equal(t, uint16(0x8000), scale16(0.5))
+}
@@ -2,13 +2,16 @@ package hue
 
 import (
 	"context"
-	"errors"
 	"net/http"
 	"strconv"
 
 	funk "github.com/thoas/go-funk"
 )
 
+// SetStateParams changes the state of a light, or of all lights in a group with GroupService.SetState.
+//
+// The increments BriInc, SatInc, HueInc and CtInc are *int, use Int(-20) to decrease a value.
+// They used to be *uint8 and *uint16, which couldn't hold negative increments.
 type SetStateParams struct {
 	On             *bool     `json:"on,omitempty"`
 	Bri            *uint8    `json:"bri,omitempty"`
@@ -19,11 +22,11 @@ type SetStateParams struct {
 	CT             *uint16   `json:"ct,omitempty"`
 	Alert          *string   `json:"alert,omitempty"`
 	TransitionTime *uint16   `json:"transitiontime,omitempty"`
-	BriInc         *uint8    `json:"bri_inc,omitempty"`
-	SatInc         *uint8    `json:"sat_inc,omitempty"`
-	HueInc         *uint16   `json:"hue_inc,omitempty"`
-	CtInc          *uint16   `json:"ct_inc,omitempty"`
-	XYInc          []float32 `json:"xy_inc,omitempty"`
+	BriInc         *int      `json:"bri_inc,omitempty"` // -254 to 254, negative values decrease
+	SatInc         *int      `json:"sat_inc,omitempty"` // -254 to 254
+	HueInc         *int      `json:"hue_inc,omitempty"` // -65534 to 65534
+	CtInc          *int      `json:"ct_inc,omitempty"`  // -65534 to 65534
+	XYInc          []float32 `json:"xy_inc,omitempty"`  // -0.5 to 0.5 for x and y
 	Scene          *string   `json:"scene,omitempty"`
 }
 
@@ -113,8 +116,8 @@ func (s *LightService) Search(ctx context.Context) (*Response, error) {
 		return resp, err
 	}
 
-	if len(apiResponses) == 0 || (apiResponses)[0].Error != nil {
-		return resp, errors.New((apiResponses)[0].Error.Description)
+	if len(apiResponses) == 0 {
+		return resp, ErrInvalidResponse
 	}
 
 	return resp, nil
@@ -136,8 +139,8 @@ func (s *LightService) Rename(ctx context.Context, id, name string) (*Response,
 		return resp, err
 	}
 
-	if len(apiResponses) == 0 || (apiResponses)[0].Error != nil {
-		return resp, errors.New((apiResponses)[0].Error.Description)
+	if len(apiResponses) == 0 {
+		return resp, ErrInvalidResponse
 	}
 
 	return resp, nil
@@ -152,11 +155,7 @@ func (s *LightService) SetState(ctx context.Context, id string, payload SetState
 
 	var apiResponses []ApiResponse
 	resp, err := s.client.do(ctx, req, &apiResponses)
-	if err != nil {
-		return nil, resp, err
-	}
-
-	return apiResponses, resp, nil
+	return apiResponses, resp, err
 }
 
 // Delete a light from the bridge.
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"image/color"
+	"net/http"
 )
 
 // TurnOn sets on status as true
@@ -23,7 +24,7 @@ func (s *LightService) TurnOnAll(ctx context.Context, ids ...string) {
 	for _, id := range ids {
 		apiResponses, _, err := s.SetState(ctx, id, SetStateParams{On: Bool(true)})
 		if err != nil {
-			s.client.logger.Info("Turning on failed", "ApiResponses", apiResponses)
+			s.client.logger.Error(s.client.redactError(err), "Turning on failed", "light", id, "ApiResponses", apiResponses)
 		}
 	}
 }
@@ -33,7 +34,7 @@ func (s *LightService) TurnOffAll(ctx context.Context, ids ...string) {
 	for _, id := range ids {
 		apiResponses, _, err := s.SetState(ctx, id, SetStateParams{On: Bool(false)})
 		if err != nil {
-			s.client.logger.Info("Turning off failed", "ApiResponses", apiResponses)
+			s.client.logger.Error(s.client.redactError(err), "Turning off failed", "light", id, "ApiResponses", apiResponses)
 		}
 	}
 }
@@ -50,7 +51,7 @@ func (s *LightService) SetColor(ctx context.Context, id string, clr color.Color)
 		return err
 	}
 
-	s.client.logger.Info("Set state successful", "ApiResponses", apiResponses)
+	s.client.logger.V(LogLevelResponse).Info("Set state successful", "light", id, "ApiResponses", apiResponses)
 
 	return nil
 }
@@ -67,7 +68,12 @@ func (s *LightService) SetColorHex(ctx context.Context, id string, hex string) e
 		return err
 	}
 
-	s.client.logger.Info("Set state successful", "ApiResponses", apiResponses)
+	s.client.logger.V(LogLevelResponse).Info("Set state successful", "light", id, "ApiResponses", apiResponses)
 
 	return nil
 }
+
+// StateCommand returns the command which sets the state of the light, e.g. to use in a schedule
+func (s *LightService) StateCommand(id string, payload SetStateParams) *Command {
+	return &Command{Address: "/" + defaultBasePath + s.lightServicePath(id, "state"), Method: http.MethodPut, Body: payload}
+}
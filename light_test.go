@@ -3,6 +3,7 @@ package hue
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -200,3 +201,45 @@ func TestLightService_Delete(t *testing.T) {
 
 	assert.Equal(t, http.StatusOK, resp.Response.StatusCode)
 }
+
+func TestLightService_SetState_Error(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_SetStateError.json")
+	mux.HandleFunc("/username/lights/1/state", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Lights.SetState(ctx, "1", SetStateParams{On: Bool(true), Bri: UInt8(200)})
+	if err == nil {
+		t.Fatalf("Light.SetState returned no error")
+	}
+
+	assert.Len(t, got, 3)
+	assert.True(t, errors.Is(err, ErrDeviceOff))
+	assert.True(t, errors.Is(err, ErrInvalidValue))
+
+	var multiErr MultiError
+	if assert.True(t, errors.As(err, &multiErr)) {
+		assert.Len(t, multiErr, 2)
+	}
+}
+
+func TestLightService_Rename_Empty(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/username/lights/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, "[]")
+	})
+
+	ctx := context.Background()
+	_, err := client.Lights.Rename(ctx, "1", "new_name")
+	assert.Equal(t, ErrInvalidResponse, err)
+}
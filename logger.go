@@ -0,0 +1,76 @@
+package hue
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/bombsimon/logrusr"
+	"github.com/go-logr/logr"
+	"github.com/sirupsen/logrus"
+)
+
+// Verbosity levels of the client logs, failures are logged with Error
+const (
+	LogLevelInfo     = 0 // Notable events, e.g. a missing clientId
+	LogLevelRequest  = 1 // Every request with method, path, status and duration
+	LogLevelResponse = 2 // Payloads returned by the bridge, e.g. the ApiResponses of a state change
+)
+
+// redacted replaces usernames in logged paths
+const redacted = "<redacted>"
+
+// newLogger returns the logger of opts, or a logrus logger with the level of opts
+func newLogger(opts *ClientOptions) logr.Logger {
+	if opts != nil && opts.Logger != nil {
+		return opts.Logger
+	}
+
+	l := logrus.New()
+	if opts != nil && opts.LogLevel != 0 {
+		l.SetLevel(opts.LogLevel)
+	}
+	return logrusr.NewLogger(l)
+}
+
+// redactPath returns the path with the username of the client and whitelisted usernames redacted,
+// e.g. /api/<redacted>/lights/1/state
+func (c *Client) redactPath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		if s == "" {
+			continue
+		}
+		if s == c.clientId || (i > 0 && segments[i-1] == "whitelist") {
+			segments[i] = redacted
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+// redactedError is an error with the usernames in its request URL redacted, it unwraps to the original error
+type redactedError struct {
+	err error
+	msg string
+}
+
+func (e *redactedError) Error() string { return e.msg }
+func (e *redactedError) Unwrap() error { return e.err }
+
+// redactError returns err for logging. Errors of http.Client contain the request URL, its path is
+// redacted and the query dropped, e.g. Get "http://host/api/<redacted>/lights": dial tcp ...
+func (c *Client) redactError(err error) error {
+	var urlErr *url.Error
+	if !errors.As(err, &urlErr) {
+		return err
+	}
+
+	u, parseErr := url.Parse(urlErr.URL)
+	if parseErr != nil {
+		return &redactedError{err: err, msg: strings.Replace(err.Error(), urlErr.URL, redacted, -1)}
+	}
+	redactedURL := u.Scheme + "://" + u.Host + c.redactPath(u.EscapedPath())
+
+	msg := strings.Replace(err.Error(), urlErr.URL, redactedURL, -1)
+	return &redactedError{err: err, msg: msg}
+}
@@ -0,0 +1,92 @@
+//go:build go1.21
+// +build go1.21
+
+package hue
+
+import (
+	"context"
+	"log/slog"
+	"runtime"
+	"time"
+
+	"github.com/go-logr/logr"
+)
+
+// NewSlogLogger returns a logger for ClientOptions.Logger which writes to the slog handler.
+// V(n) is logged with slog.Level(-n), so slog.LevelDebug shows requests and payloads.
+func NewSlogLogger(handler slog.Handler) logr.Logger {
+	return &slogLogger{handler: handler}
+}
+
+type slogLogger struct {
+	handler slog.Handler
+	level   int
+	name    string
+}
+
+func (l *slogLogger) Enabled() bool {
+	return l.handler.Enabled(context.Background(), slog.Level(-l.level))
+}
+
+func (l *slogLogger) Info(msg string, keysAndValues ...interface{}) {
+	l.log(slog.Level(-l.level), msg, keysAndValues)
+}
+
+func (l *slogLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	if err != nil {
+		keysAndValues = append([]interface{}{"err", err}, keysAndValues...)
+	}
+	l.log(slog.LevelError, msg, keysAndValues)
+}
+
+func (l *slogLogger) V(level int) logr.InfoLogger {
+	c := *l
+	c.level += level
+	return &c
+}
+
+func (l *slogLogger) WithValues(keysAndValues ...interface{}) logr.Logger {
+	c := *l
+	c.handler = l.handler.WithAttrs(slogAttrs(keysAndValues))
+	return &c
+}
+
+func (l *slogLogger) WithName(name string) logr.Logger {
+	c := *l
+	if c.name != "" {
+		c.name += "/"
+	}
+	c.name += name
+	return &c
+}
+
+func (l *slogLogger) log(level slog.Level, msg string, keysAndValues []interface{}) {
+	ctx := context.Background()
+	if !l.handler.Enabled(ctx, level) {
+		return
+	}
+
+	// Skip Callers, log and Info or Error
+	var pcs [1]uintptr
+	runtime.Callers(3, pcs[:])
+
+	r := slog.NewRecord(time.Now(), level, msg, pcs[0])
+	if l.name != "" {
+		r.AddAttrs(slog.String("logger", l.name))
+	}
+	r.AddAttrs(slogAttrs(keysAndValues)...)
+	_ = l.handler.Handle(ctx, r)
+}
+
+// slogAttrs converts logr key value pairs, a missing value is logged as !BADKEY like slog does
+func slogAttrs(keysAndValues []interface{}) []slog.Attr {
+	r := slog.Record{}
+	r.Add(keysAndValues...)
+
+	attrs := make([]slog.Attr, 0, r.NumAttrs())
+	r.Attrs(func(a slog.Attr) bool {
+		attrs = append(attrs, a)
+		return true
+	})
+	return attrs
+}
@@ -0,0 +1,68 @@
+//go:build go1.21
+// +build go1.21
+
+package hue
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSlogLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewSlogLogger(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.Level(-LogLevelRequest)}))
+
+	assert.True(t, logger.Enabled())
+	assert.True(t, logger.V(LogLevelRequest).Enabled())
+	assert.False(t, logger.V(LogLevelResponse).Enabled())
+
+	logger = logger.WithName("hue").WithValues("bridge", testBridgeId)
+	logger.V(LogLevelRequest).Info("Request sent", "method", http.MethodGet, "status", http.StatusOK)
+	logger.V(LogLevelResponse).Info("Set state successful")
+	logger.Error(errors.New("boom"), "Turning on failed", "light", "1")
+
+	var entries []map[string]interface{}
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var entry map[string]interface{}
+		assert.NoError(t, dec.Decode(&entry))
+		entries = append(entries, entry)
+	}
+
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "DEBUG+3", entries[0]["level"])
+		assert.Equal(t, "Request sent", entries[0]["msg"])
+		assert.Equal(t, "hue", entries[0]["logger"])
+		assert.Equal(t, testBridgeId, entries[0]["bridge"])
+		assert.Equal(t, http.MethodGet, entries[0]["method"])
+		assert.Equal(t, float64(http.StatusOK), entries[0]["status"])
+
+		assert.Equal(t, "ERROR", entries[1]["level"])
+		assert.Equal(t, "boom", entries[1]["err"])
+		assert.Equal(t, "1", entries[1]["light"])
+	}
+}
+
+func TestNewSlogLogger_Client(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var buf bytes.Buffer
+	client.logger = NewSlogLogger(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	mux.HandleFunc("/username/lights/1/state", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"success":{"/lights/1/state/on":true}}]`)
+	})
+
+	assert.NoError(t, client.Lights.TurnOn(context.Background(), "1"))
+	assert.Contains(t, buf.String(), "path=/api/<redacted>/lights/1/state")
+	assert.NotContains(t, buf.String(), "username")
+}
@@ -0,0 +1,166 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+)
+
+type logEntry struct {
+	level         int
+	msg           string
+	keysAndValues map[string]interface{}
+}
+
+// recordLogger records the entries up to the verbosity level max
+type recordLogger struct {
+	max     int
+	level   int
+	entries *[]logEntry
+}
+
+func newRecordLogger(max int) *recordLogger {
+	return &recordLogger{max: max, entries: &[]logEntry{}}
+}
+
+func (l *recordLogger) Enabled() bool { return l.level <= l.max }
+
+func (l *recordLogger) Info(msg string, keysAndValues ...interface{}) {
+	if l.Enabled() {
+		l.record(l.level, msg, keysAndValues)
+	}
+}
+
+func (l *recordLogger) Error(err error, msg string, keysAndValues ...interface{}) {
+	l.record(-1, msg, append(keysAndValues, "error", err.Error()))
+}
+
+func (l *recordLogger) V(level int) logr.InfoLogger {
+	c := *l
+	c.level += level
+	return &c
+}
+
+func (l *recordLogger) WithValues(keysAndValues ...interface{}) logr.Logger { return l }
+func (l *recordLogger) WithName(name string) logr.Logger                    { return l }
+
+func (l *recordLogger) record(level int, msg string, keysAndValues []interface{}) {
+	kv := map[string]interface{}{}
+	for i := 0; i+1 < len(keysAndValues); i += 2 {
+		kv[fmt.Sprint(keysAndValues[i])] = keysAndValues[i+1]
+	}
+	*l.entries = append(*l.entries, logEntry{level: level, msg: msg, keysAndValues: kv})
+}
+
+func TestLogger_Requests(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	logger := newRecordLogger(LogLevelRequest)
+	client.logger = logger
+
+	mux.HandleFunc("/username/lights/1/state", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"success":{"/lights/1/state/on":true}}]`)
+	})
+	mux.HandleFunc("/username/config/whitelist/otheruser", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bridge is busy", http.StatusServiceUnavailable)
+	})
+
+	ctx := context.Background()
+	err := client.Lights.SetColor(ctx, "1", testColor)
+	assert.NoError(t, err)
+	_, err = client.Config.DeleteWhitelist(ctx, "otheruser")
+	assert.Error(t, err)
+
+	entries := *logger.entries
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, LogLevelRequest, entries[0].level)
+		assert.Equal(t, "Request sent", entries[0].msg)
+		assert.Equal(t, http.MethodPut, entries[0].keysAndValues["method"])
+		assert.Equal(t, "/api/<redacted>/lights/1/state", entries[0].keysAndValues["path"])
+		assert.Equal(t, http.StatusOK, entries[0].keysAndValues["status"])
+		assert.IsType(t, time.Duration(0), entries[0].keysAndValues["duration"])
+		assert.NotContains(t, entries[0].keysAndValues, "error")
+
+		assert.Equal(t, http.MethodDelete, entries[1].keysAndValues["method"])
+		assert.Equal(t, "/api/<redacted>/config/whitelist/<redacted>", entries[1].keysAndValues["path"])
+		assert.Equal(t, http.StatusServiceUnavailable, entries[1].keysAndValues["status"])
+		assert.Contains(t, entries[1].keysAndValues["error"], "503")
+	}
+}
+
+func TestLogger_Responses(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/username/lights/1/state", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"success":{"/lights/1/state/on":true}}]`)
+	})
+
+	// Success payloads aren't logged at info
+	logger := newRecordLogger(LogLevelInfo)
+	client.logger = logger
+	assert.NoError(t, client.Lights.SetColorHex(context.Background(), "1", testColorHex))
+	assert.Empty(t, *logger.entries)
+
+	logger = newRecordLogger(LogLevelResponse)
+	client.logger = logger
+	assert.NoError(t, client.Lights.SetColorHex(context.Background(), "1", testColorHex))
+	entries := *logger.entries
+	if assert.Len(t, entries, 2) {
+		assert.Equal(t, "Request sent", entries[0].msg)
+		assert.Equal(t, LogLevelResponse, entries[1].level)
+		assert.Equal(t, "Set state successful", entries[1].msg)
+		assert.Equal(t, "1", entries[1].keysAndValues["light"])
+	}
+}
+
+func TestNewLogger(t *testing.T) {
+	logger := newRecordLogger(LogLevelInfo)
+	assert.Equal(t, logger, newLogger(&ClientOptions{Logger: logger}))
+
+	// The default logrus logger is at info level
+	assert.True(t, newLogger(nil).Enabled())
+	assert.False(t, newLogger(nil).V(LogLevelRequest).Enabled())
+	assert.True(t, newLogger(&ClientOptions{LogLevel: logrus.DebugLevel}).V(LogLevelRequest).Enabled())
+	assert.False(t, newLogger(&ClientOptions{LogLevel: logrus.DebugLevel}).V(LogLevelResponse).Enabled())
+	assert.True(t, newLogger(&ClientOptions{LogLevel: logrus.TraceLevel}).V(LogLevelResponse).Enabled())
+}
+
+func TestLogger_ConnectionError(t *testing.T) {
+	// Nothing listens at the address of a closed server, so requests fail with *url.Error
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	u, _ := url.Parse(server.URL)
+	logger := newRecordLogger(LogLevelResponse)
+	client := NewClient(u.Host, "secretuser", &ClientOptions{Logger: logger})
+
+	ctx := context.Background()
+	_, _, err := client.Lights.GetAll(ctx)
+	var urlErr *url.Error
+	assert.True(t, errors.As(err, &urlErr), "unexpected error: %v", err)
+	client.Lights.TurnOnAll(ctx, "1")
+
+	entries := *logger.entries
+	if !assert.Len(t, entries, 3) {
+		return
+	}
+	assert.Contains(t, entries[0].keysAndValues["error"], "/api/<redacted>/lights")
+	for _, e := range entries {
+		assert.NotContains(t, e.msg, "secretuser")
+		for k, v := range e.keysAndValues {
+			assert.NotContains(t, k, "secretuser")
+			assert.NotContains(t, fmt.Sprint(v), "secretuser", "logged as %v", k)
+		}
+	}
+}
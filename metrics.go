@@ -0,0 +1,66 @@
+package hue
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// RequestMetric describes a finished request to the bridge
+type RequestMetric struct {
+	BridgeID   string        // ClientOptions.BridgeID, empty if it isn't set
+	Service    string        // e.g. lights or groups, empty for users and the full state
+	Operation  string        // One of Operation*
+	StatusCode int           // HTTP status, 0 if no response was received
+	Duration   time.Duration // Including retries and waiting for the rate limit
+	Retries    int
+	ErrorTypes []int // Error codes returned by the bridge, e.g. ErrorTypeDeviceOff
+	Err        error // Error returned to the caller
+}
+
+// Metrics records every request to the bridge, see the metrics package for a Prometheus collector.
+// ObserveRequest is called concurrently and shouldn't block.
+type Metrics interface {
+	ObserveRequest(m RequestMetric)
+}
+
+// noopMetrics is used when ClientOptions.Metrics is nil
+type noopMetrics struct{}
+
+func (noopMetrics) ObserveRequest(RequestMetric) {}
+
+// observeRequest passes a finished request to the metrics of the client
+func (c *Client) observeRequest(req *http.Request, resp *http.Response, retries int, duration time.Duration, err error) {
+	r := c.newMiddlewareRequest(req)
+	m := RequestMetric{
+		BridgeID:   c.bridgeID,
+		Service:    r.Service,
+		Operation:  r.Operation,
+		Duration:   duration,
+		Retries:    retries,
+		ErrorTypes: errorTypes(err),
+		Err:        err,
+	}
+	if resp != nil {
+		m.StatusCode = resp.StatusCode
+	}
+	c.metrics.ObserveRequest(m)
+}
+
+// errorTypes returns the codes of the bridge errors in err
+func errorTypes(err error) []int {
+	var multi MultiError
+	if errors.As(err, &multi) {
+		types := make([]int, 0, len(multi))
+		for _, e := range multi {
+			types = append(types, e.Type)
+		}
+		return types
+	}
+
+	var single *Error
+	if errors.As(err, &single) {
+		return []int{single.Type}
+	}
+	return nil
+}
@@ -0,0 +1,107 @@
+// Package metrics exposes the requests of hue clients to Prometheus.
+//
+//	collector := metrics.NewCollector(nil)
+//	prometheus.MustRegister(collector)
+//	client := hue.NewClient(bridge.Addr(), username, &hue.ClientOptions{BridgeID: bridge.ID, Metrics: collector})
+package metrics
+
+import (
+	"strconv"
+
+	"github.com/firstthumb/go-hue"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const defaultNamespace = "hue"
+
+// Labels of the metrics
+const (
+	LabelBridge    = "bridge"
+	LabelService   = "service"
+	LabelOperation = "operation"
+	LabelStatus    = "status" // HTTP status code, "error" if there was no response
+	LabelErrorType = "type"   // Error code of the bridge
+)
+
+// DefaultBuckets of the request duration in seconds, bridges answer in tens of milliseconds
+var DefaultBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// CollectorOptions configures the names and buckets of the metrics
+type CollectorOptions struct {
+	Namespace string    // Prefix of the metric names, hue if empty
+	Buckets   []float64 // Buckets of the request duration, DefaultBuckets if empty
+}
+
+// Collector records requests as hue.Metrics and exposes them as prometheus.Collector:
+//   - hue_requests_total counts requests by bridge, service, operation and status
+//   - hue_request_duration_seconds is a histogram by bridge, service and operation
+//   - hue_errors_total counts errors returned by the bridge by bridge, service, operation and type
+//
+// A single Collector can be shared by the clients of several bridges.
+type Collector struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+// NewCollector returns a Collector, register it with prometheus.MustRegister
+func NewCollector(opts *CollectorOptions) *Collector {
+	namespace := defaultNamespace
+	buckets := DefaultBuckets
+	if opts != nil {
+		if opts.Namespace != "" {
+			namespace = opts.Namespace
+		}
+		if len(opts.Buckets) > 0 {
+			buckets = opts.Buckets
+		}
+	}
+
+	labels := []string{LabelBridge, LabelService, LabelOperation}
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "requests_total",
+			Help:      "Number of requests to the bridge.",
+		}, append(labels, LabelStatus)),
+		duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "request_duration_seconds",
+			Help:      "Duration of requests to the bridge including retries.",
+			Buckets:   buckets,
+		}, labels),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "errors_total",
+			Help:      "Number of errors returned by the bridge by their type.",
+		}, append(labels, LabelErrorType)),
+	}
+}
+
+// ObserveRequest implements hue.Metrics
+func (c *Collector) ObserveRequest(m hue.RequestMetric) {
+	status := "error"
+	if m.StatusCode != 0 {
+		status = strconv.Itoa(m.StatusCode)
+	}
+
+	c.requests.WithLabelValues(m.BridgeID, m.Service, m.Operation, status).Inc()
+	c.duration.WithLabelValues(m.BridgeID, m.Service, m.Operation).Observe(m.Duration.Seconds())
+	for _, t := range m.ErrorTypes {
+		c.errors.WithLabelValues(m.BridgeID, m.Service, m.Operation, strconv.Itoa(t)).Inc()
+	}
+}
+
+// Describe implements prometheus.Collector
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.duration.Describe(ch)
+	c.errors.Describe(ch)
+}
+
+// Collect implements prometheus.Collector
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.duration.Collect(ch)
+	c.errors.Collect(ch)
+}
@@ -0,0 +1,85 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/firstthumb/go-hue"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+const testBridgeID = "001788FFFE23BFC2"
+
+func TestCollector(t *testing.T) {
+	collector := NewCollector(&CollectorOptions{Buckets: []float64{.1, 1}})
+	registry := prometheus.NewPedanticRegistry()
+	assert.NoError(t, registry.Register(collector))
+
+	collector.ObserveRequest(hue.RequestMetric{
+		BridgeID:   testBridgeID,
+		Service:    "lights",
+		Operation:  hue.OperationSetState,
+		StatusCode: 200,
+		Duration:   50 * time.Millisecond,
+		ErrorTypes: []int{hue.ErrorTypeDeviceOff},
+		Err:        hue.ErrDeviceOff,
+	})
+	collector.ObserveRequest(hue.RequestMetric{
+		BridgeID:   testBridgeID,
+		Service:    "lights",
+		Operation:  hue.OperationSetState,
+		StatusCode: 200,
+		Duration:   500 * time.Millisecond,
+	})
+	collector.ObserveRequest(hue.RequestMetric{
+		BridgeID:  testBridgeID,
+		Service:   "groups",
+		Operation: hue.OperationGetAll,
+		Duration:  2 * time.Second,
+		Err:       errors.New("connection refused"),
+	})
+
+	expected := `
+# HELP hue_errors_total Number of errors returned by the bridge by their type.
+# TYPE hue_errors_total counter
+hue_errors_total{bridge="001788FFFE23BFC2",operation="setstate",service="lights",type="201"} 1
+# HELP hue_request_duration_seconds Duration of requests to the bridge including retries.
+# TYPE hue_request_duration_seconds histogram
+hue_request_duration_seconds_bucket{bridge="001788FFFE23BFC2",operation="getall",service="groups",le="0.1"} 0
+hue_request_duration_seconds_bucket{bridge="001788FFFE23BFC2",operation="getall",service="groups",le="1"} 0
+hue_request_duration_seconds_bucket{bridge="001788FFFE23BFC2",operation="getall",service="groups",le="+Inf"} 1
+hue_request_duration_seconds_sum{bridge="001788FFFE23BFC2",operation="getall",service="groups"} 2
+hue_request_duration_seconds_count{bridge="001788FFFE23BFC2",operation="getall",service="groups"} 1
+hue_request_duration_seconds_bucket{bridge="001788FFFE23BFC2",operation="setstate",service="lights",le="0.1"} 1
+hue_request_duration_seconds_bucket{bridge="001788FFFE23BFC2",operation="setstate",service="lights",le="1"} 2
+hue_request_duration_seconds_bucket{bridge="001788FFFE23BFC2",operation="setstate",service="lights",le="+Inf"} 2
+hue_request_duration_seconds_sum{bridge="001788FFFE23BFC2",operation="setstate",service="lights"} 0.55
+hue_request_duration_seconds_count{bridge="001788FFFE23BFC2",operation="setstate",service="lights"} 2
+# HELP hue_requests_total Number of requests to the bridge.
+# TYPE hue_requests_total counter
+hue_requests_total{bridge="001788FFFE23BFC2",operation="getall",service="groups",status="error"} 1
+hue_requests_total{bridge="001788FFFE23BFC2",operation="setstate",service="lights",status="200"} 2
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected)))
+}
+
+func TestCollector_Namespace(t *testing.T) {
+	collector := NewCollector(&CollectorOptions{Namespace: "home"})
+	collector.ObserveRequest(hue.RequestMetric{Service: "lights", Operation: hue.OperationGet, StatusCode: 200})
+
+	count, err := testutil.GatherAndCount(registryWith(t, collector), "home_requests_total", "home_request_duration_seconds")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+}
+
+func registryWith(t *testing.T, c prometheus.Collector) *prometheus.Registry {
+	registry := prometheus.NewRegistry()
+	assert.NoError(t, registry.Register(c))
+	return registry
+}
+
+var _ hue.Metrics = (*Collector)(nil)
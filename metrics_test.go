@@ -0,0 +1,93 @@
+package hue
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordMetrics struct {
+	mu      sync.Mutex
+	metrics []RequestMetric
+}
+
+func (r *recordMetrics) ObserveRequest(m RequestMetric) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.metrics = append(r.metrics, m)
+}
+
+func TestMetrics(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	metrics := &recordMetrics{}
+	client.metrics = metrics
+	client.bridgeID = testBridgeId
+
+	mux.HandleFunc("/username/lights/1/state", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"error":{"type":201,"address":"/lights/1/state/bri","description":"parameter, bri, is not modifiable. Device is set to off."}},`+
+			`{"error":{"type":7,"address":"/lights/1/state/hue","description":"invalid value, 70000, for parameter, hue"}}]`)
+	})
+	mux.HandleFunc("/username/groups", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	})
+	mux.HandleFunc("/username/groups/1", func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "bridge is busy", http.StatusServiceUnavailable)
+	})
+
+	ctx := context.Background()
+	client.Lights.SetState(ctx, "1", SetStateParams{Bri: UInt8(100)})
+	client.Groups.GetAll(ctx)
+	client.Groups.Get(ctx, "1")
+
+	if assert.Len(t, metrics.metrics, 3) {
+		m := metrics.metrics[0]
+		assert.Equal(t, testBridgeId, m.BridgeID)
+		assert.Equal(t, lightServiceName, m.Service)
+		assert.Equal(t, OperationSetState, m.Operation)
+		assert.Equal(t, http.StatusOK, m.StatusCode)
+		assert.Equal(t, []int{ErrorTypeDeviceOff, ErrorTypeInvalidValue}, m.ErrorTypes)
+		assert.Error(t, m.Err)
+		assert.True(t, m.Duration > 0)
+
+		m = metrics.metrics[1]
+		assert.Equal(t, groupServiceName, m.Service)
+		assert.Equal(t, OperationGetAll, m.Operation)
+		assert.Equal(t, http.StatusOK, m.StatusCode)
+		assert.Empty(t, m.ErrorTypes)
+		assert.NoError(t, m.Err)
+
+		m = metrics.metrics[2]
+		assert.Equal(t, OperationGet, m.Operation)
+		assert.Equal(t, http.StatusServiceUnavailable, m.StatusCode)
+		assert.Empty(t, m.ErrorTypes)
+		assert.Error(t, m.Err)
+	}
+}
+
+func TestMetrics_NoResponse(t *testing.T) {
+	client, _, _, teardown := setup()
+	teardown()
+
+	metrics := &recordMetrics{}
+	client.metrics = metrics
+
+	_, _, err := client.Lights.GetAll(context.Background())
+	assert.Error(t, err)
+	if assert.Len(t, metrics.metrics, 1) {
+		assert.Equal(t, 0, metrics.metrics[0].StatusCode)
+		assert.Equal(t, err, metrics.metrics[0].Err)
+	}
+}
+
+func TestErrorTypes(t *testing.T) {
+	assert.Nil(t, errorTypes(nil))
+	assert.Nil(t, errorTypes(&HTTPError{StatusCode: http.StatusBadGateway}))
+	assert.Equal(t, []int{ErrorTypeUnauthorizedUser}, errorTypes(fmt.Errorf("wrapped: %w", ErrUnauthorizedUser)))
+	assert.Equal(t, []int{ErrorTypeDeviceOff, ErrorTypeInvalidValue}, errorTypes(MultiError{ErrDeviceOff, ErrInvalidValue}))
+}
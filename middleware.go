@@ -0,0 +1,104 @@
+package hue
+
+import (
+	"net/http"
+	"strings"
+)
+
+// Operations of a Request
+const (
+	OperationGetAll   = "getall"   // GET of a list, e.g. lights
+	OperationGetNew   = "getnew"   // GET of lights/new or sensors/new
+	OperationGet      = "get"      // GET of a resource or its attributes
+	OperationCreate   = "create"   // POST of a resource or user
+	OperationSearch   = "search"   // POST to lights or sensors, searching for new devices
+	OperationUpdate   = "update"   // PUT of attributes
+	OperationSetState = "setstate" // PUT of lights/<id>/state, groups/<id>/action or sensors/<id>/state
+	OperationDelete   = "delete"   // DELETE of a resource
+)
+
+// Request is a request to the bridge as it is passed to middlewares
+type Request struct {
+	*http.Request
+
+	Service   string // e.g. lights or groups, empty for users and the full state
+	ID        string // Id of the resource, empty if the request isn't for a single resource
+	Operation string // One of Operation*
+}
+
+// RoundTrip sends the request to the bridge
+type RoundTrip func(req *Request) (*http.Response, error)
+
+// Middleware wraps every request sent to the bridge, e.g. to add headers, log or inject faults.
+// Retried requests pass the middlewares for every attempt.
+//
+//	func tracing(next hue.RoundTrip) hue.RoundTrip {
+//		return func(req *hue.Request) (*http.Response, error) {
+//			req.Header.Set("X-Request-ID", newID())
+//			return next(req)
+//		}
+//	}
+type Middleware func(next RoundTrip) RoundTrip
+
+// chainMiddlewares returns rt wrapped by the middlewares, the first one is called first
+func chainMiddlewares(rt RoundTrip, middlewares []Middleware) RoundTrip {
+	for i := len(middlewares) - 1; i >= 0; i-- {
+		rt = middlewares[i](rt)
+	}
+	return rt
+}
+
+// newMiddlewareRequest describes req by the service, resource and operation of its path
+func (c *Client) newMiddlewareRequest(req *http.Request) *Request {
+	r := &Request{Request: req}
+
+	// The path is <base>/<username>/<service>/<id>/..., e.g. /api/username/lights/1/state
+	path := strings.TrimPrefix(req.URL.Path, c.baseURL.Path)
+	var segments []string
+	for _, s := range strings.Split(path, "/") {
+		if s != "" {
+			segments = append(segments, s)
+		}
+	}
+	if len(segments) > 1 {
+		r.Service = segments[1]
+	}
+	if len(segments) > 2 {
+		r.ID = segments[2]
+	}
+	last := ""
+	if len(segments) > 0 {
+		last = segments[len(segments)-1]
+	}
+
+	switch req.Method {
+	case http.MethodGet:
+		switch {
+		case r.ID == "new":
+			r.ID, r.Operation = "", OperationGetNew
+		case r.ID == "":
+			r.Operation = OperationGetAll
+		default:
+			r.Operation = OperationGet
+		}
+	case http.MethodPost:
+		// Lights can't be created, sensors are created with a body
+		searchLights := r.Service == lightServiceName
+		searchSensors := r.Service == sensorServiceName && req.ContentLength <= 0
+		if len(segments) == 2 && (searchLights || searchSensors) {
+			r.Operation = OperationSearch
+		} else {
+			r.Operation = OperationCreate
+		}
+	case http.MethodPut:
+		if len(segments) == 4 && (last == "state" || last == "action") {
+			r.Operation = OperationSetState
+		} else {
+			r.Operation = OperationUpdate
+		}
+	case http.MethodDelete:
+		r.Operation = OperationDelete
+	}
+
+	return r
+}
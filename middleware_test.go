@@ -0,0 +1,124 @@
+package hue
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewares(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var order []string
+	var requests []Request
+	audit := func(next RoundTrip) RoundTrip {
+		return func(req *Request) (*http.Response, error) {
+			order = append(order, "audit")
+			requests = append(requests, Request{Service: req.Service, ID: req.ID, Operation: req.Operation})
+			return next(req)
+		}
+	}
+	tracing := func(next RoundTrip) RoundTrip {
+		return func(req *Request) (*http.Response, error) {
+			order = append(order, "tracing")
+			req.Header.Set("X-Request-ID", "42")
+			return next(req)
+		}
+	}
+	client.roundTrip = chainMiddlewares(client.roundTrip, []Middleware{audit, tracing})
+
+	handle := func(pattern, file string) {
+		bytes, _ := ioutil.ReadFile(file)
+		mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "42", r.Header.Get("X-Request-ID"))
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, string(bytes))
+		})
+	}
+	handle("/username/lights", "testdata/Light_Search.json")
+	handle("/username/lights/new", "testdata/Light_GetNew.json")
+	handle("/username/lights/1/state", "testdata/Light_SetState.json")
+	handle("/username/groups/1/action", "testdata/Group_SetState.json")
+	handle("/username/scenes/4e1c6b20e-on-0", "testdata/Scene_Delete.json")
+	handle("/username/config", "testdata/Config_Update.json")
+
+	ctx := context.Background()
+	client.Lights.Search(ctx)
+	client.Lights.GetNew(ctx)
+	client.Lights.SetState(ctx, "1", SetStateParams{On: Bool(true)})
+	client.Groups.SetState(ctx, "1", SetStateParams{On: Bool(true)})
+	client.Scenes.Delete(ctx, "4e1c6b20e-on-0")
+	client.Config.Update(ctx, ConfigParams{Name: String("Hue")})
+
+	assert.Equal(t, []Request{
+		{Service: "lights", Operation: OperationSearch},
+		{Service: "lights", Operation: OperationGetNew},
+		{Service: "lights", ID: "1", Operation: OperationSetState},
+		{Service: "groups", ID: "1", Operation: OperationSetState},
+		{Service: "scenes", ID: "4e1c6b20e-on-0", Operation: OperationDelete},
+		{Service: "config", Operation: OperationUpdate},
+	}, requests)
+	assert.Equal(t, []string{"audit", "tracing"}, order[:2])
+}
+
+func TestMiddlewares_FaultInjection(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.retrier = newRetrier(&RetryPolicy{MinBackoff: time.Millisecond})
+
+	// The first attempt fails before it reaches the bridge
+	attempts := 0
+	faults := func(next RoundTrip) RoundTrip {
+		return func(req *Request) (*http.Response, error) {
+			attempts++
+			if attempts == 1 {
+				return &http.Response{
+					StatusCode: http.StatusServiceUnavailable,
+					Status:     "503 Service Unavailable",
+					Header:     http.Header{},
+					Body:       ioutil.NopCloser(strings.NewReader("")),
+					Request:    req.Request,
+				}, nil
+			}
+			return next(req)
+		}
+	}
+	client.roundTrip = chainMiddlewares(client.roundTrip, []Middleware{faults})
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_GetAll.json")
+	mux.HandleFunc("/username/lights", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	lights, resp, err := client.Lights.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("Lights.GetAll returned error: %+v", err)
+	}
+	assert.NotEmpty(t, lights)
+	assert.Equal(t, 1, resp.Retries)
+	assert.Equal(t, 2, attempts)
+}
+
+func TestClientOptions_Middlewares(t *testing.T) {
+	called := false
+	client := NewClient("localhost", "username", &ClientOptions{Middlewares: []Middleware{
+		func(next RoundTrip) RoundTrip {
+			return func(req *Request) (*http.Response, error) {
+				called = true
+				return nil, fmt.Errorf("offline")
+			}
+		},
+	}})
+
+	_, _, err := client.Lights.GetAll(context.Background())
+	assert.EqualError(t, err, "offline")
+	assert.True(t, called)
+}
@@ -0,0 +1,134 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// defaultPairInterval is how often the bridge is asked for a new user while waiting for the link button
+var defaultPairInterval = time.Second
+
+// PairingProgress is reported while waiting for the link button to be pressed
+type PairingProgress struct {
+	Attempt   int           // Number of requests sent to the bridge so far
+	Remaining time.Duration // Time left until the context deadline, zero if there is no deadline
+}
+
+// PairOptions configures Pair, the zero value polls every second
+type PairOptions struct {
+	Interval      time.Duration         // Time between attempts, defaults to one second
+	OnProgress    func(PairingProgress) // Called after every attempt the link button wasn't pressed for
+	ClientOptions *ClientOptions        // Options of the client used to reach the bridge, set CredentialStore to reuse users
+}
+
+// Pair creates a user on the bridge and waits until the link button is pressed or ctx is done.
+// Use a context with deadline, the bridge accepts new users for 30 seconds after the button is pressed.
+// If the options have a CredentialStore, the stored user of the bridge is returned without pairing
+// and a new user is saved. The bridge is asked for its ID when BridgeID isn't set,
+// with TLSOptions over HTTPS and its certificate must be issued for that ID.
+//
+//	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+//	defer cancel()
+//	creds, err := hue.Pair(ctx, host, "my_app#my_device", &hue.PairOptions{
+//		OnProgress: func(p hue.PairingProgress) { fmt.Printf("Press the link button, %v left\n", p.Remaining) },
+//	})
+func Pair(ctx context.Context, host, deviceType string, opts *PairOptions) (*Credentials, error) {
+	if opts == nil {
+		opts = &PairOptions{}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultPairInterval
+	}
+
+	clientOpts := opts.ClientOptions
+	if clientOpts != nil && clientOpts.TLS != nil && clientOpts.BridgeID == "" {
+		// The certificate is verified for the bridge ID, so it must be known before the client is created
+		bridgeID, err := probeTLSBridgeID(ctx, host, clientOpts)
+		if err != nil {
+			return nil, err
+		}
+		o := *clientOpts
+		o.BridgeID = bridgeID
+		clientOpts = &o
+	}
+
+	c, err := newLocalClient(host, clientOpts)
+	if err != nil {
+		return nil, err
+	}
+
+	if c.store != nil {
+		if c.bridgeID == "" {
+			bridge, err := probeBridge(ctx, c.client, "http", bridgeAt(host))
+			if err != nil {
+				return nil, err
+			}
+			c.bridgeID = bridge.ID
+		}
+
+		c.loadCredentials()
+		if c.clientId != "" {
+			return &Credentials{Username: c.clientId, ClientKey: c.clientKey}, nil
+		}
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for attempt := 1; ; attempt++ {
+		creds, err := c.createUser(ctx, deviceType)
+		if err == nil {
+			// The user exists on the bridge now, so it is returned even if it couldn't be saved
+			return creds, c.saveCredentials(creds)
+		}
+		if !errors.Is(err, ErrLinkButtonNotPressed) {
+			return nil, err
+		}
+
+		if opts.OnProgress != nil {
+			progress := PairingProgress{Attempt: attempt}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) > 0 {
+				progress.Remaining = time.Until(deadline)
+			}
+			opts.OnProgress(progress)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("hue: link button wasn't pressed: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// createUser asks the bridge once for a new user with a client key
+func (c *Client) createUser(ctx context.Context, deviceType string) (*Credentials, error) {
+	payload := createUserRequest{DeviceType: deviceType, GenerateClientKey: true}
+	req, err := c.newRequest(http.MethodPost, "", payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResponses []ApiResponse
+	_, err = c.do(ctx, req, &apiResponses)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(apiResponses) == 0 {
+		return nil, ErrInvalidResponse
+	}
+
+	username, ok := apiResponses[0].Success["username"].(string)
+	if !ok {
+		return nil, ErrInvalidResponse
+	}
+	// Bridges before API 1.22 don't generate client keys
+	clientKey, _ := apiResponses[0].Success["clientkey"].(string)
+
+	return &Credentials{Username: username, ClientKey: clientKey}, nil
+}
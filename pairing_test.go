@@ -0,0 +1,123 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// handleCreateUser answers with link button not pressed until the given attempt
+func handleCreateUser(t *testing.T, mux *http.ServeMux, pressedAt int) *int {
+	created, _ := ioutil.ReadFile("testdata/User_Create.json")
+	notPressed, _ := ioutil.ReadFile("testdata/User_LinkButtonNotPressed.json")
+
+	attempts := 0
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload createUserRequest
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, createUserRequest{DeviceType: "go-hue#test", GenerateClientKey: true}, payload)
+
+		attempts++
+		w.Header().Set("Content-Type", "application/json")
+		if attempts < pressedAt {
+			fmt.Fprint(w, string(notPressed))
+		} else {
+			fmt.Fprint(w, string(created))
+		}
+	})
+	return &attempts
+}
+
+func TestPair(t *testing.T) {
+	_, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	attempts := handleCreateUser(t, mux, 3)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	var progress []PairingProgress
+	u, _ := url.Parse(serverURL)
+	got, err := Pair(ctx, u.Host, "go-hue#test", &PairOptions{
+		Interval:   time.Millisecond,
+		OnProgress: func(p PairingProgress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("Pair returned error: %+v", err)
+	}
+
+	want := &Credentials{Username: "83b7780291a6ceffbe0bd049104df", ClientKey: "33DDAD5A3AF9A33A4C6C6C9A8A7C8F7E"}
+	assert.Equal(t, want, got)
+	assert.Equal(t, 3, *attempts)
+	assert.Len(t, progress, 2)
+	assert.Equal(t, 2, progress[1].Attempt)
+	assert.True(t, progress[1].Remaining > 0 && progress[1].Remaining <= 5*time.Second)
+}
+
+func TestPair_Timeout(t *testing.T) {
+	_, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	handleCreateUser(t, mux, 1000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	u, _ := url.Parse(serverURL)
+	_, err := Pair(ctx, u.Host, "go-hue#test", &PairOptions{Interval: 10 * time.Millisecond})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestPair_Error(t *testing.T) {
+	_, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_Unauthorized.json")
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	u, _ := url.Parse(serverURL)
+	_, err := Pair(context.Background(), u.Host, "go-hue#test", nil)
+	assert.True(t, errors.Is(err, ErrUnauthorizedUser))
+}
+
+func TestCreateUser(t *testing.T) {
+	_, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	handleCreateUser(t, mux, 1)
+
+	u, _ := url.Parse(serverURL)
+	client, err := CreateUser(u.Host, "go-hue#test", nil)
+	if err != nil {
+		t.Fatalf("CreateUser returned error: %+v", err)
+	}
+
+	assert.Equal(t, "83b7780291a6ceffbe0bd049104df", client.GetClientID())
+	assert.Equal(t, "33DDAD5A3AF9A33A4C6C6C9A8A7C8F7E", client.GetClientKey())
+}
+
+func TestCreateUser_LinkButtonNotPressed(t *testing.T) {
+	_, mux, serverURL, teardown := setup()
+	defer teardown()
+
+	attempts := handleCreateUser(t, mux, 2)
+
+	u, _ := url.Parse(serverURL)
+	_, err := CreateUser(u.Host, "go-hue#test", nil)
+	assert.True(t, errors.Is(err, ErrLinkButtonNotPressed))
+	assert.Equal(t, 1, *attempts)
+}
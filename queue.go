@@ -0,0 +1,310 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// Largest increments the bridge accepts in both directions, accumulated increments are capped to them
+const (
+	maxBriInc = 254
+	maxSatInc = 254
+	maxHueInc = 65534
+	maxCtInc  = 65534
+	maxXYInc  = 0.5
+)
+
+// Ranges of the absolute values, the bridge rejects the whole state change if one is out of range
+const (
+	minBri = 1 // 0 isn't off, use On
+	maxBri = 254
+	minCT  = 153 // 6500K
+	maxCT  = 500 // 2000K
+)
+
+// ErrQueueClosed is returned for commands queued after the CommandQueue was closed
+var ErrQueueClosed = errors.New("hue: command queue is closed")
+
+// CommandQueue merges state changes of the same light or group which are waiting to be sent.
+// It is meant for fast changing input like sliders, only the latest state is sent.
+// Commands are sent at the rate limit of the client, or the default RateLimit if it has none.
+type CommandQueue struct {
+	client  *Client
+	limiter *rateLimiter
+
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mu       sync.Mutex
+	pending  map[queueTarget]*QueuedCommand // Waiting to be sent, later changes are merged into them
+	inflight map[queueTarget]*QueuedCommand // Being sent
+	sending  map[queueTarget]bool           // Whether a goroutine sends the commands of the target
+	closed   bool
+	wg       sync.WaitGroup
+}
+
+// queueTarget is a light or group
+type queueTarget struct {
+	Type ResourceType
+	ID   string
+}
+
+// QueuedCommand is the result of a queued state change, it is shared by every change merged into it
+type QueuedCommand struct {
+	params    SetStateParams
+	done      chan struct{}
+	responses []ApiResponse
+	err       error
+}
+
+// NewCommandQueue returns a queue which sends commands with the client
+func NewCommandQueue(client *Client) *CommandQueue {
+	q := &CommandQueue{
+		client:   client,
+		pending:  make(map[queueTarget]*QueuedCommand),
+		inflight: make(map[queueTarget]*QueuedCommand),
+		sending:  make(map[queueTarget]bool),
+	}
+	// The client waits for its own limiter already
+	if client.limiter == nil {
+		q.limiter = newRateLimiter(&RateLimit{})
+	}
+	q.ctx, q.cancel = context.WithCancel(context.Background())
+	return q
+}
+
+// SetLightState queues a state change of the light, it is merged with the pending change of the light
+func (q *CommandQueue) SetLightState(id string, params SetStateParams) *QueuedCommand {
+	return q.enqueue(queueTarget{Type: ResourceTypeLight, ID: id}, params)
+}
+
+// SetGroupState queues a state change of the group, it is merged with the pending change of the group
+func (q *CommandQueue) SetGroupState(id string, params SetStateParams) *QueuedCommand {
+	return q.enqueue(queueTarget{Type: ResourceTypeGroup, ID: id}, params)
+}
+
+func (q *CommandQueue) enqueue(target queueTarget, params SetStateParams) *QueuedCommand {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if q.closed {
+		cmd := &QueuedCommand{done: make(chan struct{}), err: ErrQueueClosed}
+		close(cmd.done)
+		return cmd
+	}
+
+	if cmd, ok := q.pending[target]; ok {
+		mergeStateParams(&cmd.params, params)
+		return cmd
+	}
+
+	cmd := &QueuedCommand{done: make(chan struct{})}
+	mergeStateParams(&cmd.params, params)
+	q.pending[target] = cmd
+
+	if !q.sending[target] {
+		q.sending[target] = true
+		q.wg.Add(1)
+		go q.send(target)
+	}
+	return cmd
+}
+
+// send sends the pending commands of target one after the other, until there is no pending command
+func (q *CommandQueue) send(target queueTarget) {
+	defer q.wg.Done()
+
+	for {
+		q.mu.Lock()
+		delete(q.inflight, target)
+		cmd, ok := q.pending[target]
+		if !ok {
+			delete(q.sending, target)
+			q.mu.Unlock()
+			return
+		}
+		// Changes queued from now on are merged into the next command
+		delete(q.pending, target)
+		q.inflight[target] = cmd
+		q.mu.Unlock()
+
+		cmd.responses, cmd.err = q.sendCommand(target, cmd.params)
+		close(cmd.done)
+	}
+}
+
+func (q *CommandQueue) sendCommand(target queueTarget, params SetStateParams) ([]ApiResponse, error) {
+	if q.limiter != nil {
+		bucket := q.limiter.lights
+		if target.Type == ResourceTypeGroup {
+			bucket = q.limiter.groups
+		}
+		if err := bucket.wait(q.ctx); err != nil {
+			return nil, err
+		}
+	}
+
+	var responses []ApiResponse
+	var err error
+	if target.Type == ResourceTypeGroup {
+		responses, _, err = q.client.Groups.SetState(q.ctx, target.ID, params)
+	} else {
+		responses, _, err = q.client.Lights.SetState(q.ctx, target.ID, params)
+	}
+	return responses, err
+}
+
+// Flush waits until the commands queued before were sent or ctx is done
+func (q *CommandQueue) Flush(ctx context.Context) error {
+	q.mu.Lock()
+	cmds := make([]*QueuedCommand, 0, len(q.pending)+len(q.inflight))
+	for _, cmd := range q.pending {
+		cmds = append(cmds, cmd)
+	}
+	for _, cmd := range q.inflight {
+		cmds = append(cmds, cmd)
+	}
+	q.mu.Unlock()
+
+	for _, cmd := range cmds {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-cmd.done:
+		}
+	}
+	return nil
+}
+
+// Close stops the queue, commands which weren't sent yet fail with context.Canceled.
+// Use Flush before to send them.
+func (q *CommandQueue) Close() {
+	q.mu.Lock()
+	q.closed = true
+	q.mu.Unlock()
+
+	q.cancel()
+	q.wg.Wait()
+}
+
+// Done is closed when the command was sent
+func (c *QueuedCommand) Done() <-chan struct{} {
+	return c.done
+}
+
+// Wait waits until the command was sent and returns the responses of the bridge
+func (c *QueuedCommand) Wait(ctx context.Context) ([]ApiResponse, error) {
+	select {
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	case <-c.done:
+		return c.responses, c.err
+	}
+}
+
+// mergeStateParams applies src on dst like the bridge would apply them one after the other.
+// Fields of src override the ones of dst, increments accumulate or are added to the absolute value of dst.
+func mergeStateParams(dst *SetStateParams, src SetStateParams) {
+	if src.On != nil {
+		dst.On = Bool(*src.On)
+	}
+	if src.Effect != nil {
+		dst.Effect = String(*src.Effect)
+	}
+	if src.Alert != nil {
+		dst.Alert = String(*src.Alert)
+	}
+	if src.TransitionTime != nil {
+		dst.TransitionTime = uint16Ptr(*src.TransitionTime)
+	}
+	if src.Scene != nil {
+		dst.Scene = String(*src.Scene)
+	}
+
+	if src.Bri != nil {
+		dst.Bri, dst.BriInc = UInt8(*src.Bri), nil
+	}
+	if src.BriInc != nil {
+		if dst.Bri != nil {
+			dst.Bri = UInt8(uint8(clamp(float64(*dst.Bri)+float64(*src.BriInc), minBri, maxBri)))
+		} else {
+			dst.BriInc = addInc(dst.BriInc, *src.BriInc, maxBriInc)
+		}
+	}
+
+	if src.Sat != nil {
+		dst.Sat, dst.SatInc = UInt8(*src.Sat), nil
+	}
+	if src.SatInc != nil {
+		if dst.Sat != nil {
+			dst.Sat = UInt8(uint8(clamp(float64(*dst.Sat)+float64(*src.SatInc), 0, 254)))
+		} else {
+			dst.SatInc = addInc(dst.SatInc, *src.SatInc, maxSatInc)
+		}
+	}
+
+	if src.Hue != nil {
+		dst.Hue, dst.HueInc = uint16Ptr(*src.Hue), nil
+	}
+	if src.HueInc != nil {
+		if dst.Hue != nil {
+			// Hue is an angle, it wraps around in both directions
+			dst.Hue = uint16Ptr(uint16(((int(*dst.Hue)+*src.HueInc)%65536 + 65536) % 65536))
+		} else {
+			dst.HueInc = addInc(dst.HueInc, *src.HueInc, maxHueInc)
+		}
+	}
+
+	if src.CT != nil {
+		dst.CT, dst.CtInc = uint16Ptr(*src.CT), nil
+	}
+	if src.CtInc != nil {
+		if dst.CT != nil {
+			dst.CT = uint16Ptr(uint16(clamp(float64(*dst.CT)+float64(*src.CtInc), minCT, maxCT)))
+		} else {
+			dst.CtInc = addInc(dst.CtInc, *src.CtInc, maxCtInc)
+		}
+	}
+
+	if src.XY != nil {
+		dst.XY, dst.XYInc = append([]float64(nil), src.XY...), nil
+	}
+	if len(src.XYInc) == 2 {
+		switch {
+		case len(dst.XY) == 2:
+			dst.XY = []float64{
+				clamp(dst.XY[0]+float64(src.XYInc[0]), 0, 1),
+				clamp(dst.XY[1]+float64(src.XYInc[1]), 0, 1),
+			}
+		case len(dst.XYInc) == 2:
+			dst.XYInc = []float32{
+				float32(clamp(float64(dst.XYInc[0]+src.XYInc[0]), -maxXYInc, maxXYInc)),
+				float32(clamp(float64(dst.XYInc[1]+src.XYInc[1]), -maxXYInc, maxXYInc)),
+			}
+		default:
+			dst.XYInc = append([]float32(nil), src.XYInc...)
+		}
+	}
+}
+
+func clamp(v, min, max float64) float64 {
+	if v < min {
+		return min
+	}
+	if v > max {
+		return max
+	}
+	return v
+}
+
+func uint16Ptr(v uint16) *uint16 { return &v }
+
+// addInc returns the sum of the increments capped to -max and max
+func addInc(inc *int, v int, max int) *int {
+	sum := v
+	if inc != nil {
+		sum += *inc
+	}
+	return Int(int(clamp(float64(sum), float64(-max), float64(max))))
+}
@@ -0,0 +1,170 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCommandQueue_Merge(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_SetState.json")
+	received := make(chan map[string]interface{}, 10)
+	release := make(chan struct{})
+	mux.HandleFunc(fmt.Sprintf("/username/lights/%s/state", testLightId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+		received <- payload
+
+		// The first command is held back, so the next ones are queued meanwhile
+		<-release
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	queue := NewCommandQueue(client)
+	defer queue.Close()
+
+	first := queue.SetLightState(testLightId, SetStateParams{Bri: UInt8(10)})
+	assert.Equal(t, map[string]interface{}{"bri": float64(10)}, <-received)
+
+	second := queue.SetLightState(testLightId, SetStateParams{BriInc: Int(5), On: Bool(true)})
+	third := queue.SetLightState(testLightId, SetStateParams{BriInc: Int(5), On: Bool(false)})
+	assert.True(t, second == third)
+	close(release)
+
+	assert.Equal(t, map[string]interface{}{"bri_inc": float64(10), "on": false}, <-received)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err := first.Wait(ctx)
+	assert.NoError(t, err)
+	responses, err := third.Wait(ctx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, responses)
+
+	assert.NoError(t, queue.Flush(ctx))
+	assert.Len(t, received, 0)
+}
+
+func TestCommandQueue_Close(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Group_SetState.json")
+	mux.HandleFunc(fmt.Sprintf("/username/groups/%s/action", testGroupId), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	queue := NewCommandQueue(client)
+
+	// One group command per second, so the second one is still waiting when the queue is closed
+	first := queue.SetGroupState(testGroupId, SetStateParams{On: Bool(true)})
+	<-first.Done()
+	second := queue.SetGroupState(testGroupId, SetStateParams{On: Bool(false)})
+	queue.Close()
+
+	_, err := second.Wait(context.Background())
+	assert.True(t, errors.Is(err, context.Canceled))
+
+	_, err = queue.SetGroupState(testGroupId, SetStateParams{On: Bool(true)}).Wait(context.Background())
+	assert.True(t, errors.Is(err, ErrQueueClosed))
+}
+
+func TestMergeStateParams(t *testing.T) {
+	tests := []struct {
+		name string
+		cmds []SetStateParams
+		want SetStateParams
+	}{
+		{
+			name: "later fields override",
+			cmds: []SetStateParams{{On: Bool(true), Bri: UInt8(100)}, {Bri: UInt8(200), Effect: String("colorloop")}},
+			want: SetStateParams{On: Bool(true), Bri: UInt8(200), Effect: String("colorloop")},
+		},
+		{
+			name: "increments accumulate",
+			cmds: []SetStateParams{{BriInc: Int(100), HueInc: Int(1000)}, {BriInc: Int(200), HueInc: Int(500)}},
+			want: SetStateParams{BriInc: Int(254), HueInc: Int(1500)},
+		},
+		{
+			name: "increment is added to absolute value",
+			cmds: []SetStateParams{{Bri: UInt8(100), XY: []float64{0.25, 0.5}}, {BriInc: Int(10), XYInc: []float32{0.25, 0.75}}},
+			want: SetStateParams{Bri: UInt8(110), XY: []float64{0.5, 1}},
+		},
+		{
+			name: "absolute value drops increment",
+			cmds: []SetStateParams{{CtInc: Int(50), SatInc: Int(5)}, {CT: uint16Ptr(300)}},
+			want: SetStateParams{CT: uint16Ptr(300), SatInc: Int(5)},
+		},
+		{
+			name: "hue wraps around",
+			cmds: []SetStateParams{{Hue: uint16Ptr(65000)}, {HueInc: Int(1000)}},
+			want: SetStateParams{Hue: uint16Ptr(464)},
+		},
+		{
+			name: "negative increments accumulate",
+			cmds: []SetStateParams{
+				{BriInc: Int(-100), SatInc: Int(-20), HueInc: Int(-1000), CtInc: Int(-50), XYInc: []float32{-0.125, -0.25}},
+				{BriInc: Int(-50), SatInc: Int(-30), HueInc: Int(-500), CtInc: Int(-25), XYInc: []float32{-0.125, -0.125}},
+			},
+			want: SetStateParams{BriInc: Int(-150), SatInc: Int(-50), HueInc: Int(-1500), CtInc: Int(-75), XYInc: []float32{-0.25, -0.375}},
+		},
+		{
+			name: "negative increments are capped",
+			cmds: []SetStateParams{
+				{BriInc: Int(-200), HueInc: Int(-60000), XYInc: []float32{-0.375, 0}},
+				{BriInc: Int(-200), HueInc: Int(-60000), XYInc: []float32{-0.375, 0}},
+			},
+			want: SetStateParams{BriInc: Int(-254), HueInc: Int(-65534), XYInc: []float32{-0.5, 0}},
+		},
+		{
+			name: "mixed sign increments",
+			cmds: []SetStateParams{
+				{BriInc: Int(50), SatInc: Int(-10), CtInc: Int(100), XYInc: []float32{0.25, -0.25}},
+				{BriInc: Int(-80), SatInc: Int(30), CtInc: Int(-100), XYInc: []float32{-0.5, 0.125}},
+			},
+			want: SetStateParams{BriInc: Int(-30), SatInc: Int(20), CtInc: Int(0), XYInc: []float32{-0.25, -0.125}},
+		},
+		{
+			name: "negative increment is subtracted from absolute value",
+			cmds: []SetStateParams{{Bri: UInt8(120), CT: uint16Ptr(300)}, {BriInc: Int(-50), CtInc: Int(-100)}},
+			want: SetStateParams{Bri: UInt8(70), CT: uint16Ptr(200)},
+		},
+		{
+			name: "merged absolute values stay in the range of the bridge",
+			cmds: []SetStateParams{{Bri: UInt8(20), CT: uint16Ptr(200)}, {BriInc: Int(-50), CtInc: Int(-100)}},
+			want: SetStateParams{Bri: UInt8(1), CT: uint16Ptr(153)},
+		},
+		{
+			name: "merged absolute values stay below the maximum",
+			cmds: []SetStateParams{{Bri: UInt8(200), CT: uint16Ptr(450)}, {BriInc: Int(100), CtInc: Int(100)}},
+			want: SetStateParams{Bri: UInt8(254), CT: uint16Ptr(500)},
+		},
+		{
+			name: "hue wraps around below zero",
+			cmds: []SetStateParams{{Hue: uint16Ptr(500)}, {HueInc: Int(-1000)}},
+			want: SetStateParams{Hue: uint16Ptr(65036)},
+		},
+	}
+
+	for _, tt := range tests {
+		var got SetStateParams
+		for _, cmd := range tt.cmds {
+			mergeStateParams(&got, cmd)
+		}
+		assert.Equal(t, tt.want, got, tt.name)
+	}
+}
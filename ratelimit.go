@@ -0,0 +1,128 @@
+package hue
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Documented guidance of the bridge for commands per second
+const (
+	defaultLightCommandsPerSecond = 10
+	defaultGroupCommandsPerSecond = 1
+)
+
+// RateLimit configures client side rate limiting of commands, so the bridge doesn't drop them.
+// The zero value follows the guidance of the bridge, 10 light and 1 group command per second.
+type RateLimit struct {
+	Lights float64 // Commands per second to lights/<id>/state, defaults to 10
+	Groups float64 // Commands per second to groups/<id>/action, defaults to 1
+	Burst  int     // Commands which are sent at once before waiting, defaults to 1
+}
+
+// rateLimiter delays commands to lights and groups, other requests aren't limited
+type rateLimiter struct {
+	lights *tokenBucket
+	groups *tokenBucket
+}
+
+func newRateLimiter(limit *RateLimit) *rateLimiter {
+	lights, groups, burst := limit.Lights, limit.Groups, limit.Burst
+	if lights <= 0 {
+		lights = defaultLightCommandsPerSecond
+	}
+	if groups <= 0 {
+		groups = defaultGroupCommandsPerSecond
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &rateLimiter{
+		lights: newTokenBucket(lights, burst),
+		groups: newTokenBucket(groups, burst),
+	}
+}
+
+// wait blocks until req may be sent or ctx is done
+func (l *rateLimiter) wait(ctx context.Context, req *http.Request) error {
+	if bucket := l.bucket(req); bucket != nil {
+		return bucket.wait(ctx)
+	}
+	return nil
+}
+
+// bucket returns the bucket of the command or nil if req isn't limited
+func (l *rateLimiter) bucket(req *http.Request) *tokenBucket {
+	if req.Method != http.MethodPut {
+		return nil
+	}
+
+	// The path is e.g. /api/<username>/lights/1/state, or /bridge/... for the remote API
+	segments := strings.Split(strings.TrimSuffix(req.URL.Path, "/"), "/")
+	if len(segments) < 3 {
+		return nil
+	}
+	resource, command := segments[len(segments)-3], segments[len(segments)-1]
+
+	switch {
+	case resource == lightServiceName && command == "state":
+		return l.lights
+	case resource == groupServiceName && command == "action":
+		return l.groups
+	default:
+		return nil
+	}
+}
+
+// tokenBucket allows rate commands per second, burst of them at once
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(rate float64, burst int) *tokenBucket {
+	return &tokenBucket{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait takes a token, it waits until the token is available or ctx is done
+func (b *tokenBucket) wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+
+	// The token is taken even if it isn't available yet, so waiting commands keep their order
+	b.tokens--
+	var delay time.Duration
+	if b.tokens < 0 {
+		delay = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if delay == 0 {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		// The command isn't sent, so its token is given back
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
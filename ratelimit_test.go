@@ -0,0 +1,95 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// setupRateLimit returns a client with limit, it records when commands reach the bridge
+func setupRateLimit(t *testing.T, limit *RateLimit) (*Client, *[]time.Time, func()) {
+	client, mux, _, teardown := setup()
+	client.limiter = newRateLimiter(limit)
+
+	var mu sync.Mutex
+	var sent []time.Time
+	lightState, _ := ioutil.ReadFile("testdata/Light_SetState.json")
+	groupState, _ := ioutil.ReadFile("testdata/Group_SetState.json")
+	record := func(bytes []byte) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			sent = append(sent, time.Now())
+			mu.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, string(bytes))
+		}
+	}
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		mux.HandleFunc(fmt.Sprintf("/username/lights/%s/state", id), record(lightState))
+	}
+	mux.HandleFunc("/username/groups/1/action", record(groupState))
+
+	return client, &sent, teardown
+}
+
+func TestRateLimit_Lights(t *testing.T) {
+	client, sent, teardown := setupRateLimit(t, &RateLimit{Lights: 50, Burst: 2})
+	defer teardown()
+
+	start := time.Now()
+	client.Lights.TurnOnAll(context.Background(), "1", "2", "3", "4", "5")
+
+	// Two commands are sent at once, the other three every 20ms
+	assert.Len(t, *sent, 5)
+	assert.True(t, time.Since(start) >= 55*time.Millisecond)
+	assert.True(t, (*sent)[1].Sub(start) < 20*time.Millisecond)
+}
+
+func TestRateLimit_GroupsCancel(t *testing.T) {
+	client, sent, teardown := setupRateLimit(t, &RateLimit{Groups: 0.5})
+	defer teardown()
+
+	ctx := context.Background()
+	_, _, err := client.Groups.SetState(ctx, testGroupId, SetStateParams{On: Bool(true)})
+	assert.NoError(t, err)
+
+	// The next group command may only be sent in two seconds
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Millisecond)
+	defer cancel()
+	_, _, err = client.Groups.SetState(ctx, testGroupId, SetStateParams{On: Bool(false)})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+	assert.Len(t, *sent, 1)
+
+	// Light commands have their own bucket
+	_, _, err = client.Lights.SetState(context.Background(), testLightId, SetStateParams{On: Bool(true)})
+	assert.NoError(t, err)
+}
+
+func TestRateLimiter_Bucket(t *testing.T) {
+	limiter := newRateLimiter(&RateLimit{})
+
+	tests := []struct {
+		method string
+		path   string
+		want   *tokenBucket
+	}{
+		{http.MethodPut, "/api/username/lights/1/state", limiter.lights},
+		{http.MethodPut, "/bridge/username/lights/1/state", limiter.lights},
+		{http.MethodPut, "/api/username/groups/0/action", limiter.groups},
+		{http.MethodGet, "/api/username/lights/1/state", nil},
+		{http.MethodPut, "/api/username/lights/1", nil},
+		{http.MethodPut, "/api/username/sensors/1/state", nil},
+	}
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.path, nil)
+		assert.True(t, limiter.bucket(req) == tt.want, "%v %v", tt.method, tt.path)
+	}
+}
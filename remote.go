@@ -0,0 +1,188 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/oauth2"
+)
+
+// defaultRemoteUserTimeout is how long CreateRemoteUser tries to create the user
+const defaultRemoteUserTimeout = 30 * time.Second
+
+// ErrNotRemote is returned by functions of the remote API when the client is connected to the local bridge
+var ErrNotRemote = errors.New("hue: client doesn't use the remote API")
+
+// NewRemoteClient returns a client which reaches the bridge through the remote API.
+// Tokens of src are refreshed when they expire, e.g. src of oauth2.Config.TokenSource.
+// It provides the same services as a local client. Create the username with CreateRemoteUserContext,
+// it is loaded from the credential store of opts if empty.
+func NewRemoteClient(src oauth2.TokenSource, username string, opts *ClientOptions) *Client {
+	o := ClientOptions{}
+	if opts != nil {
+		o = *opts
+	}
+
+	var base http.RoundTripper
+	var timeout time.Duration
+	if o.HttpClient != nil {
+		base = o.HttpClient.Transport
+		timeout = o.HttpClient.Timeout
+	}
+	o.HttpClient = &http.Client{
+		Transport: &oauth2.Transport{Source: oauth2.ReuseTokenSource(nil, bearerTokenSource{src: src}), Base: base},
+		Timeout:   timeout,
+	}
+
+	c, err := newClient(ApiURL, &o)
+	if err != nil {
+		return nil
+	}
+	c.remote = true
+	c.clientId = username
+	if username == "" {
+		c.loadCredentials()
+	}
+
+	return c
+}
+
+// IsRemote reports whether the client reaches the bridge through the remote API
+func (c *Client) IsRemote() bool {
+	return c.remote
+}
+
+func (c *Client) Login(username string) error {
+	c.clientId = username
+	return nil
+}
+
+// CreateRemoteUser creates a user through the remote API, it tries for 30 seconds.
+// See CreateRemoteUserContext.
+func (c *Client) CreateRemoteUser() (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), defaultRemoteUserTimeout)
+	defer cancel()
+
+	return c.CreateRemoteUserContext(ctx, nil)
+}
+
+// CreateRemoteUserContext presses the link button of the bridge through the remote API and creates a user.
+// The bridge may take a while to accept the pressed button, so it is retried until ctx is done.
+// The username is used by the client and saved in the credential store.
+func (c *Client) CreateRemoteUserContext(ctx context.Context, opts *PairOptions) (string, error) {
+	if !c.remote {
+		return "", ErrNotRemote
+	}
+	if opts == nil {
+		opts = &PairOptions{}
+	}
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultPairInterval
+	}
+
+	deviceType := c.deviceType
+	if deviceType == "" {
+		deviceType = c.userAgent
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for attempt := 1; ; attempt++ {
+		err := c.enableLinkButton(ctx)
+		if err == nil {
+			var creds *Credentials
+			creds, err = c.createUser(ctx, deviceType)
+			if err == nil {
+				c.clientId = creds.Username
+				c.clientKey = creds.ClientKey
+				// The user exists on the bridge now, so it is returned even if it couldn't be saved
+				return creds.Username, c.saveCredentials(creds)
+			}
+		}
+		if !isRetryableRemoteError(err) {
+			return "", err
+		}
+		c.logger.V(LogLevelRequest).Info("Bridge didn't accept the user yet", "attempt", attempt, "error", c.redactError(err).Error())
+
+		if opts.OnProgress != nil {
+			progress := PairingProgress{Attempt: attempt}
+			if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) > 0 {
+				progress.Remaining = time.Until(deadline)
+			}
+			opts.OnProgress(progress)
+		}
+
+		select {
+		case <-ctx.Done():
+			return "", fmt.Errorf("hue: link button wasn't pressed: %w", ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// isRetryableRemoteError reports whether creating the remote user may succeed later
+func isRetryableRemoteError(err error) bool {
+	if errors.Is(err, ErrLinkButtonNotPressed) {
+		return true
+	}
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode == http.StatusTooManyRequests || httpErr.StatusCode >= 500
+	}
+	return false
+}
+
+// EnableLinkButton presses the link button of the bridge, it only works through the remote API
+func (c *Client) EnableLinkButton() error {
+	return c.enableLinkButton(context.Background())
+}
+
+func (c *Client) enableLinkButton(ctx context.Context) error {
+	payload := ConfigParams{LinkButton: Bool(true)}
+	req, err := c.newRequest(http.MethodPut, "0/config", payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = c.do(ctx, req, nil)
+	return err
+}
+
+// AddWhitelistIdentifier creates a user while the link button is pressed and returns its username
+func (c *Client) AddWhitelistIdentifier() (string, error) {
+	creds, err := c.createUser(context.Background(), c.deviceType)
+	if err != nil {
+		return "", err
+	}
+	return creds.Username, nil
+}
+
+// bearerTokenSource fixes the type of the tokens of src
+type bearerTokenSource struct {
+	src oauth2.TokenSource
+}
+
+func (s bearerTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.src.Token()
+	if err != nil {
+		return nil, err
+	}
+	return fixTokenType(token), nil
+}
+
+// fixTokenType returns token with the type Bearer.
+// Hue response includes {"token_type": "BearerToken"},
+// But we need "Bearer" when you call back.
+func fixTokenType(token *oauth2.Token) *oauth2.Token {
+	if token.TokenType == "Bearer" {
+		return token
+	}
+	fixed := *token
+	fixed.TokenType = "Bearer"
+	return &fixed
+}
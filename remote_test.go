@@ -0,0 +1,178 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+)
+
+// setupRemote returns a remote client for a test server which serves the remote API under /bridge/
+func setupRemote(src oauth2.TokenSource, opts *ClientOptions) (client *Client, mux *http.ServeMux, teardown func()) {
+	mux = http.NewServeMux()
+
+	apiHandler := http.NewServeMux()
+	apiHandler.Handle("/bridge/", http.StripPrefix("/bridge", mux))
+
+	server := httptest.NewServer(apiHandler)
+
+	client = NewRemoteClient(src, "", opts)
+	client.baseURL, _ = url.Parse(server.URL + "/bridge/")
+
+	return client, mux, server.Close
+}
+
+func handleAuthorization(t *testing.T, mux *http.ServeMux, pattern, file string, want *string) {
+	bytes, _ := ioutil.ReadFile(file)
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, *want, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+}
+
+func TestRemoteClient_Lights(t *testing.T) {
+	src := &testTokenSource{tokens: []*oauth2.Token{
+		{AccessToken: "first", TokenType: "BearerToken", Expiry: time.Now().Add(time.Hour)},
+	}}
+	client, mux, teardown := setupRemote(src, nil)
+	defer teardown()
+
+	assert.True(t, client.IsRemote())
+	client.Login("username")
+
+	want := "Bearer first"
+	handleAuthorization(t, mux, "/username/lights", "testdata/Light_GetAll.json", &want)
+
+	ctx := context.Background()
+	lights, _, err := client.Lights.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("Lights.GetAll returned error: %+v", err)
+	}
+	assert.NotEmpty(t, lights)
+}
+
+func TestRemoteClient_RefreshToken(t *testing.T) {
+	// Tokens are refreshed 10 seconds before they expire
+	src := &testTokenSource{tokens: []*oauth2.Token{
+		{AccessToken: "first", TokenType: "BearerToken", Expiry: time.Now().Add(10*time.Second + 100*time.Millisecond)},
+		{AccessToken: "refreshed", TokenType: "BearerToken", Expiry: time.Now().Add(time.Hour)},
+	}}
+	client, mux, teardown := setupRemote(src, nil)
+	defer teardown()
+
+	client.Login("username")
+
+	want := "Bearer first"
+	handleAuthorization(t, mux, "/username/lights", "testdata/Light_GetAll.json", &want)
+
+	ctx := context.Background()
+	_, _, err := client.Lights.GetAll(ctx)
+	assert.NoError(t, err)
+
+	time.Sleep(200 * time.Millisecond)
+
+	want = "Bearer refreshed"
+	_, _, err = client.Lights.GetAll(ctx)
+	assert.NoError(t, err)
+}
+
+func TestRemoteClient_CreateRemoteUser(t *testing.T) {
+	src := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "access"})
+	store := NewMemoryCredentialStore()
+	client, mux, teardown := setupRemote(src, &ClientOptions{CredentialStore: store, BridgeID: testBridgeId})
+	defer teardown()
+	client.deviceType = "go-hue#test"
+
+	linkButton, _ := ioutil.ReadFile("testdata/Remote_LinkButton.json")
+	pressed := 0
+	mux.HandleFunc("/0/config", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload ConfigParams
+		getPayload(t, r, &payload)
+		assert.Equal(t, ConfigParams{LinkButton: Bool(true)}, payload)
+
+		pressed++
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(linkButton))
+	})
+	// The bridge accepts the pressed button on the second attempt
+	attempts := handleCreateUser(t, mux, 2)
+
+	var progress []PairingProgress
+	ctx := context.Background()
+	username, err := client.CreateRemoteUserContext(ctx, &PairOptions{
+		Interval:   time.Millisecond,
+		OnProgress: func(p PairingProgress) { progress = append(progress, p) },
+	})
+	if err != nil {
+		t.Fatalf("CreateRemoteUserContext returned error: %+v", err)
+	}
+
+	assert.Equal(t, "83b7780291a6ceffbe0bd049104df", username)
+	assert.Equal(t, username, client.GetClientID())
+	assert.Equal(t, 2, pressed)
+	assert.Equal(t, 2, *attempts)
+	assert.Len(t, progress, 1)
+
+	stored, _ := store.Load(testBridgeId)
+	assert.Equal(t, username, stored.Username)
+}
+
+func TestRemoteClient_CreateRemoteUser_Unauthorized(t *testing.T) {
+	src := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "revoked"})
+	client, mux, teardown := setupRemote(src, nil)
+	defer teardown()
+
+	calls := 0
+	mux.HandleFunc("/0/config", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"fault":{"faultstring":"Invalid Access Token"}}`)
+	})
+
+	ctx := context.Background()
+	_, err := client.CreateRemoteUserContext(ctx, &PairOptions{Interval: time.Millisecond})
+
+	var httpErr *HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusUnauthorized, httpErr.StatusCode)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRemoteClient_CreateRemoteUser_Timeout(t *testing.T) {
+	src := oauth2.StaticTokenSource(&oauth2.Token{AccessToken: "access"})
+	client, mux, teardown := setupRemote(src, nil)
+	defer teardown()
+	client.deviceType = "go-hue#test"
+
+	linkButton, _ := ioutil.ReadFile("testdata/Remote_LinkButton.json")
+	mux.HandleFunc("/0/config", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, string(linkButton))
+	})
+	handleCreateUser(t, mux, 1000)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	_, err := client.CreateRemoteUserContext(ctx, &PairOptions{Interval: 5 * time.Millisecond})
+	assert.True(t, errors.Is(err, context.DeadlineExceeded))
+}
+
+func TestClient_CreateRemoteUser_Local(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	assert.False(t, client.IsRemote())
+	_, err := client.CreateRemoteUserContext(context.Background(), nil)
+	assert.True(t, errors.Is(err, ErrNotRemote))
+}
@@ -0,0 +1,253 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	funk "github.com/thoas/go-funk"
+)
+
+// ResourceLinkService has functions for resource links
+type ResourceLinkService service
+
+const resourceLinkServiceName = "resourcelinks"
+
+func (s *ResourceLinkService) resourceLinkServicePath(params ...string) string {
+	return s.client.path(resourceLinkServiceName, params...)
+}
+
+// GetAll returns all resource links
+func (s *ResourceLinkService) GetAll(ctx context.Context) ([]ResourceLink, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, s.resourceLinkServicePath(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var links map[string]ResourceLink
+	resp, err := s.client.do(ctx, req, &links)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for k, l := range links {
+		id, _ := strconv.Atoi(k)
+		l.ID = id
+		links[k] = l
+	}
+
+	return funk.Values(links).([]ResourceLink), resp, nil
+}
+
+// Get returns the resource link by id
+func (s *ResourceLinkService) Get(ctx context.Context, id string) (*ResourceLink, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, s.resourceLinkServicePath(id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	link := new(ResourceLink)
+	resp, err := s.client.do(ctx, req, link)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return link, resp, nil
+}
+
+// Create creates the resource link, returns id of the created resource link
+// Name, ClassID and Links are required.
+func (s *ResourceLinkService) Create(ctx context.Context, payload ResourceLinkParams) (string, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPost, s.resourceLinkServicePath(), payload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	if err != nil {
+		return "", resp, err
+	}
+
+	if len(apiResponses) == 0 {
+		return "", resp, ErrInvalidResponse
+	}
+
+	// Get first success message
+	id, ok := apiResponses[0].Success["id"].(string)
+	if !ok {
+		return "", resp, ErrInvalidResponse
+	}
+
+	return id, resp, nil
+}
+
+// Update updates the resource link by id
+func (s *ResourceLinkService) Update(ctx context.Context, id string, payload ResourceLinkParams) (bool, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPut, s.resourceLinkServicePath(id), payload)
+	if err != nil {
+		return false, nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	if err != nil {
+		return false, resp, err
+	}
+
+	if len(apiResponses) == 0 {
+		return false, resp, ErrInvalidResponse
+	}
+
+	return true, resp, nil
+}
+
+// Delete removes the resource link, the linked resources are kept
+func (s *ResourceLinkService) Delete(ctx context.Context, id string) (*Response, error) {
+	req, err := s.client.newRequest(http.MethodDelete, s.resourceLinkServicePath(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// deleteOrder is the order resources are deleted in, rules and schedules lock the scenes they use
+var deleteOrder = map[ResourceType]int{
+	ResourceTypeRule:         0,
+	ResourceTypeSchedule:     1,
+	ResourceTypeResourceLink: 2,
+	ResourceTypeSensor:       3,
+	ResourceTypeScene:        4,
+}
+
+// DeleteRecursive removes the resource link together with the rules, schedules, scenes,
+// CLIP sensors and resource links it owns. Lights, groups and physical sensors are only
+// referenced by a link, so they are kept. Resources which are already gone are skipped,
+// as are links which aren't in the /type/id form.
+// Resources the bridge refuses to delete don't stop the others from being deleted, they are
+// returned as MultiError and the resource link is kept so DeleteRecursive can be retried.
+func (s *ResourceLinkService) DeleteRecursive(ctx context.Context, id string) error {
+	return s.deleteRecursive(ctx, id, make(map[string]bool))
+}
+
+func (s *ResourceLinkService) deleteRecursive(ctx context.Context, id string, visited map[string]bool) error {
+	if visited[id] {
+		return nil
+	}
+	visited[id] = true
+
+	link, _, err := s.Get(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	refs := make([]ResourceReference, 0, len(link.Links))
+	for _, l := range link.Links {
+		ref, err := ParseResourceReference(l)
+		if err != nil {
+			s.client.logger.Info("Skipping unknown link", "resourcelink", id, "link", l)
+			continue
+		}
+		refs = append(refs, ref)
+	}
+
+	sort.SliceStable(refs, func(i, j int) bool {
+		return deleteOrder[refs[i].Type] < deleteOrder[refs[j].Type]
+	})
+
+	var errs MultiError
+	for _, ref := range refs {
+		err := s.deleteOwned(ctx, ref, visited)
+		if err == nil || errors.Is(err, ErrResourceNotAvailable) {
+			continue
+		}
+
+		// Only errors of the bridge are specific to the resource, anything else fails the rest too
+		var multi MultiError
+		var single *Error
+		switch {
+		case errors.As(err, &multi):
+			errs = append(errs, multi...)
+		case errors.As(err, &single):
+			errs = append(errs, single)
+		default:
+			return err
+		}
+	}
+	if len(errs) > 0 {
+		return errs.errorOrNil()
+	}
+
+	// The bridge may have recycled the link when its resources were deleted
+	_, err = s.Delete(ctx, id)
+	if errors.Is(err, ErrResourceNotAvailable) {
+		return nil
+	}
+	return err
+}
+
+func (s *ResourceLinkService) deleteOwned(ctx context.Context, ref ResourceReference, visited map[string]bool) error {
+	var err error
+	switch ref.Type {
+	case ResourceTypeRule:
+		_, err = s.client.Rules.Delete(ctx, ref.ID)
+	case ResourceTypeSchedule:
+		_, err = s.client.Schedules.Delete(ctx, ref.ID)
+	case ResourceTypeScene:
+		_, err = s.client.Scenes.Delete(ctx, ref.ID)
+	case ResourceTypeResourceLink:
+		err = s.deleteRecursive(ctx, ref.ID, visited)
+	case ResourceTypeSensor:
+		var sensor *Sensor
+		sensor, _, err = s.client.Sensors.Get(ctx, ref.ID)
+		if err == nil && strings.HasPrefix(sensor.Type, "CLIP") {
+			_, err = s.client.Sensors.Delete(ctx, ref.ID)
+		}
+	}
+	return err
+}
+
+// Resolve gets the resource the reference points at. The result is *Light, *Group, *Scene,
+// *Rule, *Sensor, *Schedule or *ResourceLink depending on the type of the reference.
+//
+//	resource, _, err := client.ResourceLinks.Resolve(ctx, ref)
+//	if scene, ok := resource.(*hue.Scene); ok { ... }
+func (s *ResourceLinkService) Resolve(ctx context.Context, ref ResourceReference) (interface{}, *Response, error) {
+	var (
+		resource interface{}
+		resp     *Response
+		err      error
+	)
+	switch ref.Type {
+	case ResourceTypeLight:
+		resource, resp, err = s.client.Lights.Get(ctx, ref.ID)
+	case ResourceTypeGroup:
+		resource, resp, err = s.client.Groups.Get(ctx, ref.ID)
+	case ResourceTypeScene:
+		resource, resp, err = s.client.Scenes.Get(ctx, ref.ID)
+	case ResourceTypeRule:
+		resource, resp, err = s.client.Rules.Get(ctx, ref.ID)
+	case ResourceTypeSensor:
+		resource, resp, err = s.client.Sensors.Get(ctx, ref.ID)
+	case ResourceTypeSchedule:
+		resource, resp, err = s.client.Schedules.Get(ctx, ref.ID)
+	case ResourceTypeResourceLink:
+		resource, resp, err = s.Get(ctx, ref.ID)
+	default:
+		return nil, nil, fmt.Errorf("%w: %q", ErrInvalidResourceAddress, ref.String())
+	}
+	if err != nil {
+		return nil, resp, err
+	}
+	return resource, resp, nil
+}
@@ -0,0 +1,49 @@
+package hue
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrInvalidResourceAddress is returned when a link is not in the /type/id form
+var ErrInvalidResourceAddress = errors.New("hue: invalid resource address")
+
+// NewResourceReference returns reference to the resource, use String to get the link
+func NewResourceReference(t ResourceType, id string) ResourceReference {
+	return ResourceReference{Type: t, ID: id}
+}
+
+// ParseResourceReference parses a link like /scenes/3T2SvsxvwteNNys
+func ParseResourceReference(address string) (ResourceReference, error) {
+	parts := strings.Split(strings.TrimPrefix(address, "/"), "/")
+	if len(parts) != 2 || parts[1] == "" {
+		return ResourceReference{}, fmt.Errorf("%w: %q", ErrInvalidResourceAddress, address)
+	}
+
+	switch t := ResourceType(parts[0]); t {
+	case ResourceTypeLight, ResourceTypeGroup, ResourceTypeSchedule, ResourceTypeScene,
+		ResourceTypeSensor, ResourceTypeRule, ResourceTypeResourceLink:
+		return ResourceReference{Type: t, ID: parts[1]}, nil
+	}
+
+	return ResourceReference{}, fmt.Errorf("%w: %q", ErrInvalidResourceAddress, address)
+}
+
+// String returns the link of the resource, e.g. /lights/1
+func (r ResourceReference) String() string {
+	return fmt.Sprintf("/%v/%v", r.Type, r.ID)
+}
+
+// References parses the links of the resource link
+func (l ResourceLink) References() ([]ResourceReference, error) {
+	refs := make([]ResourceReference, 0, len(l.Links))
+	for _, link := range l.Links {
+		ref, err := ParseResourceReference(link)
+		if err != nil {
+			return nil, err
+		}
+		refs = append(refs, ref)
+	}
+	return refs, nil
+}
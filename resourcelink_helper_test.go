@@ -0,0 +1,53 @@
+package hue
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseResourceReference(t *testing.T) {
+	tests := []struct {
+		address string
+		want    ResourceReference
+		wantErr bool
+	}{
+		{address: "/lights/1", want: ResourceReference{Type: ResourceTypeLight, ID: "1"}},
+		{address: "/scenes/3T2SvsxvwteNNys", want: ResourceReference{Type: ResourceTypeScene, ID: "3T2SvsxvwteNNys"}},
+		{address: "resourcelinks/8126", want: ResourceReference{Type: ResourceTypeResourceLink, ID: "8126"}},
+		{address: "/lights", wantErr: true},
+		{address: "/lights/", wantErr: true},
+		{address: "/lights/1/state", wantErr: true},
+		{address: "/config/whitelist", wantErr: true},
+		{address: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := ParseResourceReference(tt.address)
+		if tt.wantErr {
+			assert.True(t, errors.Is(err, ErrInvalidResourceAddress), tt.address)
+			continue
+		}
+		assert.NoError(t, err, tt.address)
+		assert.Equal(t, tt.want, got, tt.address)
+	}
+}
+
+func TestResourceReference_String(t *testing.T) {
+	assert.Equal(t, "/sensors/7", NewResourceReference(ResourceTypeSensor, "7").String())
+}
+
+func TestResourceLink_References(t *testing.T) {
+	link := ResourceLink{Links: []string{"/schedules/1", "/rules/2"}}
+	got, err := link.References()
+	assert.NoError(t, err)
+	assert.Equal(t, []ResourceReference{
+		{Type: ResourceTypeSchedule, ID: "1"},
+		{Type: ResourceTypeRule, ID: "2"},
+	}, got)
+
+	link.Links = append(link.Links, "/unknown/1")
+	_, err = link.References()
+	assert.True(t, errors.Is(err, ErrInvalidResourceAddress))
+}
@@ -0,0 +1,46 @@
+package hue
+
+// ResourceLink struct that represents Philips Hue ResourceLink
+//
+// A resource link groups resources like scenes, rules and sensors which belong together,
+// so an application can find and delete them as a whole.
+type ResourceLink struct {
+	ID          int      `json:"-"`
+	Name        string   `json:"name"`        // Human readable name of the resource link
+	Description string   `json:"description"` // Description of the resource link
+	Type        string   `json:"type"`        // Always "Link"
+	ClassID     int      `json:"classid"`     // Application specific class of the link
+	Owner       string   `json:"owner"`       // Whitelist user that created the resource link
+	Recycle     bool     `json:"recycle"`     // The bridge may delete the link when the linked resources are deleted
+	Links       []string `json:"links"`       // Addresses of the linked resources, e.g. /scenes/abc123
+}
+
+// ResourceLinkParams is used to create or update a resource link, nil fields are not changed.
+// Links replace the existing ones as a whole.
+type ResourceLinkParams struct {
+	Name        *string  `json:"name,omitempty"`
+	Description *string  `json:"description,omitempty"`
+	ClassID     *int     `json:"classid,omitempty"`
+	Recycle     *bool    `json:"recycle,omitempty"`
+	Links       []string `json:"links,omitempty"`
+}
+
+// ResourceType is the first part of a resource address, e.g. lights in /lights/1
+type ResourceType string
+
+// Resource types which can be linked
+const (
+	ResourceTypeLight        ResourceType = lightServiceName
+	ResourceTypeGroup        ResourceType = groupServiceName
+	ResourceTypeSchedule     ResourceType = scheduleServiceName
+	ResourceTypeScene        ResourceType = sceneServiceName
+	ResourceTypeSensor       ResourceType = sensorServiceName
+	ResourceTypeRule         ResourceType = ruleServiceName
+	ResourceTypeResourceLink ResourceType = resourceLinkServiceName
+)
+
+// ResourceReference points at a resource of the bridge, e.g. /scenes/3T2SvsxvwteNNys
+type ResourceReference struct {
+	Type ResourceType
+	ID   string
+}
@@ -0,0 +1,290 @@
+package hue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	funk "github.com/thoas/go-funk"
+)
+
+var testResourceLinkId = "8126"
+
+func TestResourceLinkService_GetAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/ResourceLink_GetAll.json")
+	mux.HandleFunc("/username/resourcelinks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.ResourceLinks.GetAll(ctx)
+	if err != nil {
+		t.Errorf("ResourceLink.GetAll returned error: %+v", err)
+	}
+
+	var result map[string]ResourceLink
+	json.Unmarshal(bytes, &result)
+
+	for i, l := range result {
+		id, _ := strconv.Atoi(i)
+		l.ID = id
+		result[i] = l
+	}
+
+	want := funk.Values(result).([]ResourceLink)
+
+	sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+	sort.Slice(want, func(i, j int) bool { return want[i].ID < want[j].ID })
+	if !cmp.Equal(got, want) {
+		t.Errorf("ResourceLink.GetAll returned %+v, want %+v", got, want)
+	}
+}
+
+func TestResourceLinkService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/ResourceLink_Get.json")
+	mux.HandleFunc(fmt.Sprintf("/username/resourcelinks/%s", testResourceLinkId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.ResourceLinks.Get(ctx, testResourceLinkId)
+	if err != nil {
+		t.Errorf("ResourceLink.Get returned error: %+v", err)
+	}
+
+	want := &ResourceLink{}
+	json.Unmarshal(bytes, want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ResourceLink.Get returned %+v, want %+v", got, want)
+	}
+}
+
+func TestResourceLinkService_Create(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/ResourceLink_Create.json")
+	mux.HandleFunc("/username/resourcelinks", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, "Wake up", payload["name"])
+		assert.Equal(t, float64(1), payload["classid"])
+		assert.Equal(t, []interface{}{"/scenes/3T2SvsxvwteNNys"}, payload["links"])
+		assert.NotContains(t, payload, "recycle")
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.ResourceLinks.Create(ctx, ResourceLinkParams{
+		Name:    String("Wake up"),
+		ClassID: Int(1),
+		Links:   []string{NewResourceReference(ResourceTypeScene, testSceneId).String()},
+	})
+	if err != nil {
+		t.Errorf("ResourceLink.Create returned error: %+v", err)
+	}
+
+	assert.Equal(t, testResourceLinkId, got)
+}
+
+func TestResourceLinkService_Update(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/ResourceLink_Update.json")
+	mux.HandleFunc(fmt.Sprintf("/username/resourcelinks/%s", testResourceLinkId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.ResourceLinks.Update(ctx, testResourceLinkId, ResourceLinkParams{
+		Name:  String("Wake up"),
+		Links: []string{"/scenes/3T2SvsxvwteNNys"},
+	})
+	if err != nil {
+		t.Errorf("ResourceLink.Update returned error: %+v", err)
+	}
+
+	assert.True(t, got)
+}
+
+func TestResourceLinkService_Delete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/ResourceLink_Delete.json")
+	mux.HandleFunc(fmt.Sprintf("/username/resourcelinks/%s", testResourceLinkId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, err := client.ResourceLinks.Delete(ctx, testResourceLinkId)
+	if err != nil {
+		t.Errorf("ResourceLink.Delete returned error: %+v", err)
+	}
+}
+
+func TestResourceLinkService_DeleteRecursive(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	link, _ := ioutil.ReadFile("testdata/ResourceLink_Get.json")
+	notFound, _ := ioutil.ReadFile("testdata/ResourceLink_NotFound.json")
+	physicalSensor, _ := ioutil.ReadFile("testdata/Sensor_Get.json")
+	clipSensor := `{"name": "Wake up flag", "type": "CLIPGenericFlag", "modelid": "WAKEUP", "manufacturername": "go-hue", "swversion": "1.0", "uniqueid": "wakeup-flag", "state": {"flag": false}, "config": {"on": true, "reachable": true}}`
+
+	var deleted []string
+	handle := func(path, get string) {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/json")
+			switch r.Method {
+			case http.MethodGet:
+				fmt.Fprint(w, get)
+			case http.MethodDelete:
+				deleted = append(deleted, r.URL.Path)
+				fmt.Fprintf(w, `[{"success":"%s deleted."}]`, r.URL.Path)
+			default:
+				t.Errorf("Unexpected request method: %v %v", r.Method, r.URL.Path)
+			}
+		})
+	}
+	handle(fmt.Sprintf("/username/resourcelinks/%s", testResourceLinkId), string(link))
+	handle(fmt.Sprintf("/username/scenes/%s", testSceneId), "")
+	handle("/username/schedules/1", "")
+	handle("/username/rules/1", "")
+	handle("/username/sensors/2", string(physicalSensor))
+	handle("/username/sensors/7", clipSensor)
+	handle("/username/groups/1", "")
+	handle("/username/lights/1", "")
+	mux.HandleFunc("/username/resourcelinks/9351", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(notFound))
+	})
+
+	ctx := context.Background()
+	err := client.ResourceLinks.DeleteRecursive(ctx, testResourceLinkId)
+	if err != nil {
+		t.Errorf("ResourceLink.DeleteRecursive returned error: %+v", err)
+	}
+
+	assert.Equal(t, []string{
+		"/username/rules/1",
+		"/username/schedules/1",
+		"/username/sensors/7",
+		fmt.Sprintf("/username/scenes/%s", testSceneId),
+		fmt.Sprintf("/username/resourcelinks/%s", testResourceLinkId),
+	}, deleted)
+}
+
+func TestResourceLinkService_DeleteRecursive_Failures(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	link := `{"name": "Wake up", "type": "Link", "classid": 1, "links": ["/unknown/1", "/rules/1", "/lights/1/state", "/scenes/3T2SvsxvwteNNys", "/schedules/1"]}`
+
+	var deleted []string
+	mux.HandleFunc(fmt.Sprintf("/username/resourcelinks/%s", testResourceLinkId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, link)
+	})
+	mux.HandleFunc("/username/rules/1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `[{"error":{"type":8,"address":"/rules/1","description":"parameter, /rules/1, is not modifiable"}}]`)
+	})
+	for _, path := range []string{fmt.Sprintf("/username/scenes/%s", testSceneId), "/username/schedules/1"} {
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "DELETE")
+			deleted = append(deleted, r.URL.Path)
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprintf(w, `[{"success":"%s deleted."}]`, r.URL.Path)
+		})
+	}
+
+	ctx := context.Background()
+	err := client.ResourceLinks.DeleteRecursive(ctx, testResourceLinkId)
+	assert.True(t, errors.Is(err, ErrParameterNotModifiable), "unexpected error: %v", err)
+
+	var hueErr *Error
+	if assert.True(t, errors.As(err, &hueErr)) {
+		assert.Equal(t, "/rules/1", hueErr.Address)
+	}
+
+	// The resource link is kept, so the rule can be deleted by retrying
+	assert.Equal(t, []string{
+		"/username/schedules/1",
+		fmt.Sprintf("/username/scenes/%s", testSceneId),
+	}, deleted)
+}
+
+func TestResourceLinkService_Resolve(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	tests := []struct {
+		ref      ResourceReference
+		path     string
+		testdata string
+		want     interface{}
+	}{
+		{NewResourceReference(ResourceTypeLight, "1"), "/username/lights/1", "testdata/Light_Get.json", &Light{}},
+		{NewResourceReference(ResourceTypeGroup, "1"), "/username/groups/1", "testdata/Group_Get.json", &Group{}},
+		{NewResourceReference(ResourceTypeScene, testSceneId), "/username/scenes/" + testSceneId, "testdata/Scene_Get.json", &Scene{}},
+		{NewResourceReference(ResourceTypeRule, testRuleId), "/username/rules/" + testRuleId, "testdata/Rule_Get.json", &Rule{}},
+		{NewResourceReference(ResourceTypeSensor, "2"), "/username/sensors/2", "testdata/Sensor_Get.json", &Sensor{}},
+		{NewResourceReference(ResourceTypeSchedule, testScheduleId), "/username/schedules/" + testScheduleId, "testdata/Schedule_Get.json", &Schedule{}},
+		{NewResourceReference(ResourceTypeResourceLink, testResourceLinkId), "/username/resourcelinks/" + testResourceLinkId, "testdata/ResourceLink_Get.json", &ResourceLink{}},
+	}
+	for _, tt := range tests {
+		bytes, _ := ioutil.ReadFile(tt.testdata)
+		mux.HandleFunc(tt.path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, string(bytes))
+		})
+	}
+
+	ctx := context.Background()
+	for _, tt := range tests {
+		got, _, err := client.ResourceLinks.Resolve(ctx, tt.ref)
+		if err != nil {
+			t.Errorf("ResourceLink.Resolve(%v) returned error: %+v", tt.ref, err)
+			continue
+		}
+		assert.IsType(t, tt.want, got, tt.ref.String())
+	}
+
+	_, _, err := client.ResourceLinks.Resolve(ctx, ResourceReference{Type: "unknown", ID: "1"})
+	assert.True(t, errors.Is(err, ErrInvalidResourceAddress))
+}
@@ -0,0 +1,202 @@
+package hue
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Defaults of RetryPolicy
+const (
+	defaultMaxRetries = 3
+	defaultMinBackoff = 100 * time.Millisecond
+	defaultMaxBackoff = 5 * time.Second
+)
+
+// RetryPolicy retries requests which failed because the bridge was busy, e.g. with 503 or a reset connection.
+// Only idempotent requests are retried, mark others with WithRetry.
+// State changes with increments like bri_inc aren't idempotent, they would be applied twice.
+// The zero value retries 3 times, waiting 100ms, 200ms and 400ms plus jitter.
+type RetryPolicy struct {
+	MaxRetries int           // Retries after the first attempt, defaults to 3
+	MinBackoff time.Duration // Wait before the first retry, it doubles for each retry. Defaults to 100ms
+	MaxBackoff time.Duration // Longest wait between attempts, a longer Retry-After isn't retried. Defaults to 5s
+}
+
+type retryKey struct{}
+
+// WithRetry marks the requests made with ctx as safe to retry, e.g. a POST or an increment which can be sent twice
+func WithRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, retryKey{}, true)
+}
+
+// retrier sends a request again until it succeeds, the policy gives up or ctx is done
+type retrier struct {
+	policy RetryPolicy
+
+	mu   sync.Mutex
+	rand *rand.Rand
+}
+
+func newRetrier(policy *RetryPolicy) *retrier {
+	r := &retrier{policy: *policy, rand: rand.New(rand.NewSource(time.Now().UnixNano()))}
+	if r.policy.MaxRetries <= 0 {
+		r.policy.MaxRetries = defaultMaxRetries
+	}
+	if r.policy.MinBackoff <= 0 {
+		r.policy.MinBackoff = defaultMinBackoff
+	}
+	if r.policy.MaxBackoff < r.policy.MinBackoff {
+		r.policy.MaxBackoff = defaultMaxBackoff
+	}
+	return r
+}
+
+// retryable reports whether req may be sent more than once
+func retryable(req *http.Request) bool {
+	if marked, _ := req.Context().Value(retryKey{}).(bool); marked {
+		return true
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete, http.MethodOptions:
+		return true
+	case http.MethodPut:
+		return !hasIncrements(req)
+	default:
+		return false
+	}
+}
+
+// hasIncrements reports whether the body of req changes a value relatively, e.g. with bri_inc.
+// A body which can't be read or decoded is treated as having increments.
+func hasIncrements(req *http.Request) bool {
+	if req.Body == nil || req.Body == http.NoBody {
+		return false
+	}
+	if req.GetBody == nil {
+		return true
+	}
+	body, err := req.GetBody()
+	if err != nil {
+		return true
+	}
+	defer body.Close()
+
+	var attributes map[string]json.RawMessage
+	if err := json.NewDecoder(body).Decode(&attributes); err != nil {
+		return true
+	}
+	for name := range attributes {
+		if strings.HasSuffix(name, "_inc") {
+			return true
+		}
+	}
+	return false
+}
+
+// transientStatus reports whether the server may answer the request later
+func transientStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the wait before the retry with exponential backoff and jitter,
+// it is between half and the full backoff
+func (r *retrier) backoff(retry int) time.Duration {
+	backoff := r.policy.MinBackoff << uint(retry-1)
+	if backoff > r.policy.MaxBackoff || backoff <= 0 {
+		backoff = r.policy.MaxBackoff
+	}
+
+	r.mu.Lock()
+	jitter := time.Duration(r.rand.Int63n(int64(backoff/2) + 1))
+	r.mu.Unlock()
+
+	return backoff/2 + jitter
+}
+
+// retryAfter returns the wait the server asked for, 0 if it didn't
+func retryAfter(resp *http.Response) time.Duration {
+	value := resp.Header.Get("Retry-After")
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds > 0 {
+		return time.Duration(seconds) * time.Second
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		return time.Until(date)
+	}
+	return 0
+}
+
+// do sends req with send and sends it again while it fails transiently.
+// It returns the last response or error and the number of retries.
+func (r *retrier) do(ctx context.Context, req *http.Request, send func(*http.Request) (*http.Response, error)) (*http.Response, int, error) {
+	canRetry := retryable(req) && (req.Body == nil || req.GetBody != nil)
+
+	for retry := 0; ; retry++ {
+		attempt := req
+		if retry > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, retry, err
+			}
+			attempt = req.Clone(ctx)
+			attempt.Body = body
+		}
+
+		resp, err := send(attempt)
+		if !canRetry || retry >= r.policy.MaxRetries || ctx.Err() != nil {
+			return resp, retry, err
+		}
+
+		var wait time.Duration
+		switch {
+		case err != nil:
+			// Connection errors, e.g. a reset connection or a timeout
+			wait = r.backoff(retry + 1)
+		case transientStatus(resp.StatusCode):
+			wait = r.backoff(retry + 1)
+			after := retryAfter(resp)
+			if after > r.policy.MaxBackoff {
+				// The server won't answer within the policy
+				return resp, retry, nil
+			}
+			if after > wait {
+				wait = after
+			}
+		default:
+			return resp, retry, nil
+		}
+
+		// Don't wait for a retry which can't finish before the deadline
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < wait {
+			return resp, retry, err
+		}
+
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, retry, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// handleFlaky answers with status for the first failures requests and with the file afterwards
+func handleFlaky(t *testing.T, mux *http.ServeMux, pattern, file string, failures int, status int, header http.Header) *int {
+	bytes, _ := ioutil.ReadFile(file)
+	calls := 0
+	mux.HandleFunc(pattern, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls <= failures {
+			for k, v := range header {
+				w.Header()[k] = v
+			}
+			w.WriteHeader(status)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+	return &calls
+}
+
+func TestRetryPolicy_ServiceUnavailable(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.retrier = newRetrier(&RetryPolicy{MinBackoff: time.Millisecond})
+
+	calls := handleFlaky(t, mux, "/username/lights", "testdata/Light_GetAll.json", 2, http.StatusServiceUnavailable, nil)
+
+	lights, resp, err := client.Lights.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("Lights.GetAll returned error: %+v", err)
+	}
+	assert.NotEmpty(t, lights)
+	assert.Equal(t, 3, *calls)
+	assert.Equal(t, 2, resp.Retries)
+}
+
+func TestRetryPolicy_GiveUp(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.retrier = newRetrier(&RetryPolicy{MaxRetries: 2, MinBackoff: time.Millisecond})
+
+	calls := handleFlaky(t, mux, "/username/lights", "testdata/Light_GetAll.json", 10, http.StatusServiceUnavailable, nil)
+
+	_, resp, err := client.Lights.GetAll(context.Background())
+
+	var httpErr *HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+	assert.Equal(t, 3, *calls)
+	assert.Equal(t, 2, resp.Retries)
+}
+
+func TestRetryPolicy_SetStateBody(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.retrier = newRetrier(&RetryPolicy{MinBackoff: time.Millisecond})
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_SetState.json")
+	var payloads []SetStateParams
+	mux.HandleFunc(fmt.Sprintf("/username/lights/%s/state", testLightId), func(w http.ResponseWriter, r *http.Request) {
+		var payload SetStateParams
+		getPayload(t, r, &payload)
+		payloads = append(payloads, payload)
+
+		if len(payloads) == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	_, resp, err := client.Lights.SetState(context.Background(), testLightId, SetStateParams{On: Bool(true)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.Retries)
+
+	// The body is sent again
+	assert.Equal(t, []SetStateParams{{On: Bool(true)}, {On: Bool(true)}}, payloads)
+}
+
+func TestRetryPolicy_NotIdempotent(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.retrier = newRetrier(&RetryPolicy{MinBackoff: time.Millisecond})
+
+	calls := handleFlaky(t, mux, "/username/groups", "testdata/Group_CreateEntertainment.json", 1, http.StatusServiceUnavailable, nil)
+
+	ctx := context.Background()
+	_, _, err := client.Groups.CreateEntertainment(ctx, "TV", EntertainmentClassTV, []string{"1"})
+	assert.Error(t, err)
+	assert.Equal(t, 1, *calls)
+
+	// Marked requests are retried
+	*calls = 0
+	_, resp, err := client.Groups.CreateEntertainment(WithRetry(ctx), "TV", EntertainmentClassTV, []string{"1"})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.Retries)
+}
+
+func TestRetryPolicy_Increment(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.retrier = newRetrier(&RetryPolicy{MinBackoff: time.Millisecond})
+
+	calls := handleFlaky(t, mux, fmt.Sprintf("/username/lights/%s/state", testLightId), "testdata/Light_SetState.json", 1, http.StatusServiceUnavailable, nil)
+
+	ctx := context.Background()
+	_, resp, err := client.Lights.SetState(ctx, testLightId, SetStateParams{BriInc: Int(20)})
+	assert.Error(t, err)
+	assert.Equal(t, 1, *calls)
+	assert.Equal(t, 0, resp.Retries)
+
+	// A group action failing with a reset connection isn't sent again either
+	var groupCalls int32
+	mux.HandleFunc(fmt.Sprintf("/username/groups/%s/action", testGroupId), func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&groupCalls, 1)
+		hj, _ := w.(http.Hijacker)
+		conn, _, _ := hj.Hijack()
+		conn.Close()
+	})
+	_, _, err = client.Groups.SetState(ctx, testGroupId, SetStateParams{HueInc: Int(1000)})
+	assert.Error(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&groupCalls))
+
+	// Marked increments are retried
+	*calls = 0
+	_, resp, err = client.Lights.SetState(WithRetry(ctx), testLightId, SetStateParams{BriInc: Int(20)})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.Retries)
+}
+
+func TestRetryPolicy_RetryAfter(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.retrier = newRetrier(&RetryPolicy{MinBackoff: time.Millisecond, MaxBackoff: 2 * time.Second})
+
+	calls := handleFlaky(t, mux, "/username/lights", "testdata/Light_GetAll.json", 1, http.StatusTooManyRequests, http.Header{"Retry-After": {"1"}})
+
+	start := time.Now()
+	_, _, err := client.Lights.GetAll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, *calls)
+	assert.True(t, time.Since(start) >= time.Second)
+
+	// A longer wait than MaxBackoff isn't retried
+	mux.HandleFunc("/username/groups", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+	_, resp, err := client.Groups.GetAll(context.Background())
+	assert.Error(t, err)
+	assert.Equal(t, 0, resp.Retries)
+}
+
+func TestRetryPolicy_Deadline(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.retrier = newRetrier(&RetryPolicy{MinBackoff: time.Second})
+
+	calls := handleFlaky(t, mux, "/username/lights", "testdata/Light_GetAll.json", 1, http.StatusServiceUnavailable, nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+
+	// The retry can't be sent before the deadline, so the first answer is returned at once
+	start := time.Now()
+	_, _, err := client.Lights.GetAll(ctx)
+	assert.Error(t, err)
+	assert.Equal(t, 1, *calls)
+	assert.True(t, time.Since(start) < 100*time.Millisecond)
+}
+
+func TestRetryPolicy_ConnectionReset(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+	client.retrier = newRetrier(&RetryPolicy{MinBackoff: time.Millisecond})
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_GetAll.json")
+	calls := 0
+	mux.HandleFunc("/username/lights", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			// Close the connection without an answer
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	_, resp, err := client.Lights.GetAll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, resp.Retries)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	r := newRetrier(&RetryPolicy{MinBackoff: 100 * time.Millisecond, MaxBackoff: 300 * time.Millisecond})
+
+	for retry, max := range []time.Duration{100, 200, 300, 300} {
+		max *= time.Millisecond
+		backoff := r.backoff(retry + 1)
+		assert.True(t, backoff >= max/2 && backoff <= max, "retry %d waits %v", retry+1, backoff)
+	}
+}
@@ -0,0 +1,217 @@
+package hue
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	funk "github.com/thoas/go-funk"
+)
+
+// RuleService has functions for rules
+type RuleService service
+
+const ruleServiceName = "rules"
+
+func (s *RuleService) ruleServicePath(params ...string) string {
+	return s.client.path(ruleServiceName, params...)
+}
+
+// GetAll returns all rules
+func (s *RuleService) GetAll(ctx context.Context) ([]Rule, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, s.ruleServicePath(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var rules map[string]Rule
+	resp, err := s.client.do(ctx, req, &rules)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for k, r := range rules {
+		id, _ := strconv.Atoi(k)
+		r.ID = id
+		rules[k] = r
+	}
+
+	return funk.Values(rules).([]Rule), resp, nil
+}
+
+// Get returns the rule by id
+func (s *RuleService) Get(ctx context.Context, id string) (*Rule, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, s.ruleServicePath(id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rule := new(Rule)
+	resp, err := s.client.do(ctx, req, rule)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rule, resp, nil
+}
+
+// Create validates the rule and creates it, returns id of the created rule
+// Conditions and Actions are required.
+func (s *RuleService) Create(ctx context.Context, payload RuleParams) (string, *Response, error) {
+	if err := s.Validate(ctx, payload.Conditions, payload.Actions); err != nil {
+		return "", nil, err
+	}
+
+	req, err := s.client.newRequest(http.MethodPost, s.ruleServicePath(), payload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	if err != nil {
+		return "", resp, err
+	}
+
+	if len(apiResponses) == 0 {
+		return "", resp, ErrInvalidResponse
+	}
+
+	// Get first success message
+	id, ok := apiResponses[0].Success["id"].(string)
+	if !ok {
+		return "", resp, ErrInvalidResponse
+	}
+
+	return id, resp, nil
+}
+
+// Update updates the rule by id, conditions and actions are validated if they are given
+// Conditions and actions replace the existing ones as a whole.
+func (s *RuleService) Update(ctx context.Context, id string, payload RuleParams) (bool, *Response, error) {
+	if err := s.Validate(ctx, payload.Conditions, payload.Actions); err != nil {
+		return false, nil, err
+	}
+
+	req, err := s.client.newRequest(http.MethodPut, s.ruleServicePath(id), payload)
+	if err != nil {
+		return false, nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	if err != nil {
+		return false, resp, err
+	}
+
+	if len(apiResponses) == 0 {
+		return false, resp, ErrInvalidResponse
+	}
+
+	return true, resp, nil
+}
+
+// Delete removes the rule
+func (s *RuleService) Delete(ctx context.Context, id string) (*Response, error) {
+	req, err := s.client.newRequest(http.MethodDelete, s.ruleServicePath(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
+
+// Validate checks that conditions and actions point at existing lights, groups and sensors.
+// Only the resources which are referenced are fetched from the bridge.
+// Every invalid address is reported as an error of type 7, like the bridge does.
+func (s *RuleService) Validate(ctx context.Context, conditions []Condition, actions []Action) error {
+	var addresses []string
+	for _, c := range conditions {
+		addresses = append(addresses, c.Address)
+	}
+	for _, a := range actions {
+		addresses = append(addresses, a.Address)
+	}
+
+	existing := make(map[string]map[string]bool)
+	var errs MultiError
+	for _, address := range addresses {
+		resource, id, ok := splitRuleAddress(address)
+		if !ok {
+			continue
+		}
+		// Group 0 contains all lights, it is not returned by GetAll
+		if resource == groupServiceName && id == "0" {
+			continue
+		}
+
+		if _, fetched := existing[resource]; !fetched {
+			ids, err := s.resourceIDs(ctx, resource)
+			if err != nil {
+				return err
+			}
+			existing[resource] = ids
+		}
+
+		if !existing[resource][id] {
+			errs = append(errs, &Error{
+				Type:        ErrorTypeInvalidValue,
+				Address:     address,
+				Description: fmt.Sprintf("invalid value, %v, for parameter, address. %v %v doesn't exist", address, strings.TrimSuffix(resource, "s"), id),
+			})
+		}
+	}
+
+	return errs.errorOrNil()
+}
+
+// splitRuleAddress returns resource and id of addresses which refer to lights, groups or sensors
+func splitRuleAddress(address string) (string, string, bool) {
+	parts := strings.Split(strings.TrimPrefix(address, "/"), "/")
+	if len(parts) < 2 {
+		return "", "", false
+	}
+	switch parts[0] {
+	case lightServiceName, groupServiceName, sensorServiceName:
+		return parts[0], parts[1], true
+	}
+	return "", "", false
+}
+
+func (s *RuleService) resourceIDs(ctx context.Context, resource string) (map[string]bool, error) {
+	ids := make(map[string]bool)
+	switch resource {
+	case lightServiceName:
+		lights, _, err := s.client.Lights.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, l := range lights {
+			ids[strconv.Itoa(l.ID)] = true
+		}
+	case groupServiceName:
+		groups, _, err := s.client.Groups.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, g := range groups {
+			ids[strconv.Itoa(g.ID)] = true
+		}
+	case sensorServiceName:
+		sensors, _, err := s.client.Sensors.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for _, sn := range sensors {
+			ids[strconv.Itoa(sn.ID)] = true
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,107 @@
+package hue
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// LocalTimeAddress is the address of the bridge time, it can be used with In and NotIn
+const LocalTimeAddress RuleAddress = "/config/localtime"
+
+// RuleAddress is the address of a resource attribute which is used to build conditions
+//
+//	hue.SensorStateAddress("2", "buttonevent").Eq(1002)
+//	hue.SensorStateAddress("3", "presence").Stable(5 * time.Minute)
+//	hue.LocalTimeAddress.In(hue.Everyday, 22*time.Hour, 7*time.Hour)
+type RuleAddress string
+
+// SensorStateAddress returns address of a state attribute of the sensor, e.g. /sensors/2/state/buttonevent
+func SensorStateAddress(id, attribute string) RuleAddress {
+	return RuleAddress(fmt.Sprintf("/%v/%v/state/%v", sensorServiceName, id, attribute))
+}
+
+// SensorConfigAddress returns address of a config attribute of the sensor, e.g. /sensors/2/config/on
+func SensorConfigAddress(id, attribute string) RuleAddress {
+	return RuleAddress(fmt.Sprintf("/%v/%v/config/%v", sensorServiceName, id, attribute))
+}
+
+// LightStateAddress returns address of a state attribute of the light, e.g. /lights/1/state/on
+func LightStateAddress(id, attribute string) RuleAddress {
+	return RuleAddress(fmt.Sprintf("/%v/%v/state/%v", lightServiceName, id, attribute))
+}
+
+// GroupStateAddress returns address of a state attribute of the group, e.g. /groups/1/state/any_on
+func GroupStateAddress(id, attribute string) RuleAddress {
+	return RuleAddress(fmt.Sprintf("/%v/%v/state/%v", groupServiceName, id, attribute))
+}
+
+// Eq returns condition which is true when the attribute equals value
+func (a RuleAddress) Eq(value interface{}) Condition {
+	return Condition{Address: string(a), Operator: OperatorEq, Value: fmt.Sprint(value)}
+}
+
+// Gt returns condition which is true when the attribute is greater than value
+func (a RuleAddress) Gt(value int) Condition {
+	return Condition{Address: string(a), Operator: OperatorGt, Value: fmt.Sprint(value)}
+}
+
+// Lt returns condition which is true when the attribute is less than value
+func (a RuleAddress) Lt(value int) Condition {
+	return Condition{Address: string(a), Operator: OperatorLt, Value: fmt.Sprint(value)}
+}
+
+// Dx returns condition which is true when the attribute changes
+func (a RuleAddress) Dx() Condition {
+	return Condition{Address: string(a), Operator: OperatorDx}
+}
+
+// Ddx returns condition which is true d after the attribute changed
+func (a RuleAddress) Ddx(d time.Duration) Condition {
+	return Condition{Address: string(a), Operator: OperatorDdx, Value: Timer(d).String()}
+}
+
+// Stable returns condition which is true when the attribute didn't change for d
+func (a RuleAddress) Stable(d time.Duration) Condition {
+	return Condition{Address: string(a), Operator: OperatorStable, Value: Timer(d).String()}
+}
+
+// NotStable returns condition which is true when the attribute changed within d
+func (a RuleAddress) NotStable(d time.Duration) Condition {
+	return Condition{Address: string(a), Operator: OperatorNotStable, Value: Timer(d).String()}
+}
+
+// In returns condition which is true when the time is between from and to on given days, to may be before from.
+// If days is 0, the interval applies every day.
+func (a RuleAddress) In(days Weekdays, from, to time.Duration) Condition {
+	return Condition{Address: string(a), Operator: OperatorIn, Value: timeInterval(days, from, to)}
+}
+
+// NotIn returns condition which is true when the time is not between from and to on given days.
+// If days is 0, the interval applies every day.
+func (a RuleAddress) NotIn(days Weekdays, from, to time.Duration) Condition {
+	return Condition{Address: string(a), Operator: OperatorNotIn, Value: timeInterval(days, from, to)}
+}
+
+func timeInterval(days Weekdays, from, to time.Duration) string {
+	interval := fmt.Sprintf("T%v/T%v", formatClock(from), formatClock(to))
+	if days == 0 {
+		return interval
+	}
+	return fmt.Sprintf("W%03d/%v", int(days), interval)
+}
+
+// LightStateAction returns action which sets the state of the light
+func LightStateAction(id string, payload SetStateParams) Action {
+	return Action{Address: fmt.Sprintf("/%v/%v/state", lightServiceName, id), Method: http.MethodPut, Body: payload}
+}
+
+// GroupStateAction returns action which sets the state of the group, it can also recall a scene
+func GroupStateAction(id string, payload SetStateParams) Action {
+	return Action{Address: fmt.Sprintf("/%v/%v/action", groupServiceName, id), Method: http.MethodPut, Body: payload}
+}
+
+// SensorStateAction returns action which sets the state of a CLIP sensor
+func SensorStateAction(id string, payload SensorState) Action {
+	return Action{Address: fmt.Sprintf("/%v/%v/state", sensorServiceName, id), Method: http.MethodPut, Body: payload}
+}
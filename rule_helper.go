@@ -0,0 +1,15 @@
+package hue
+
+import "context"
+
+// Enable sets the status of the rule as enabled
+func (s *RuleService) Enable(ctx context.Context, id string) error {
+	_, _, err := s.Update(ctx, id, RuleParams{Status: String(RuleStatusEnabled)})
+	return err
+}
+
+// Disable sets the status of the rule as disabled
+func (s *RuleService) Disable(ctx context.Context, id string) error {
+	_, _, err := s.Update(ctx, id, RuleParams{Status: String(RuleStatusDisabled)})
+	return err
+}
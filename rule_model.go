@@ -0,0 +1,64 @@
+package hue
+
+// Rule statuses
+const (
+	RuleStatusEnabled           = "enabled"
+	RuleStatusDisabled          = "disabled"
+	RuleStatusResourceDeleted   = "resourcedeleted" // A resource used by the rule was deleted
+	RuleStatusLoopDetected      = "loopdetected"    // The rule triggers itself
+	RuleStatusMaxTriggerReached = "maxtriggerreached"
+)
+
+// Operator of a rule condition
+type Operator string
+
+const (
+	OperatorEq        Operator = "eq"         // Equals, for bool and int attributes
+	OperatorGt        Operator = "gt"         // Greater than, for int attributes
+	OperatorLt        Operator = "lt"         // Less than, for int attributes
+	OperatorDx        Operator = "dx"         // Attribute has changed, value is not used
+	OperatorDdx       Operator = "ddx"        // Delayed attribute has changed, value is a timer e.g. PT00:05:00
+	OperatorStable    Operator = "stable"     // Attribute hasn't changed for the given time
+	OperatorNotStable Operator = "not stable" // Attribute has changed within the given time
+	OperatorIn        Operator = "in"         // Current time is in the interval, only for /config/localtime
+	OperatorNotIn     Operator = "not in"     // Current time is not in the interval, only for /config/localtime
+)
+
+// Rule struct that represents Philips Hue Rule
+//
+// All conditions must be true to trigger the actions.
+type Rule struct {
+	ID             int         `json:"-"`
+	Name           string      `json:"name"`           // Name of the rule
+	Owner          string      `json:"owner"`          // Whitelist user that created the rule
+	Created        string      `json:"created"`        // Creation time of the rule
+	LastTriggered  string      `json:"lasttriggered"`  // Last time the rule triggered, "none" if never
+	TimesTriggered int         `json:"timestriggered"` // How many times the rule triggered
+	Status         string      `json:"status"`         // enabled, disabled or one of the error statuses
+	Recycle        bool        `json:"recycle"`        // The bridge may delete the rule when it runs out of space
+	Conditions     []Condition `json:"conditions"`
+	Actions        []Action    `json:"actions"`
+}
+
+// Condition of a rule, e.g. {"address": "/sensors/2/state/buttonevent", "operator": "eq", "value": "1002"}
+type Condition struct {
+	Address  string   `json:"address"`         // Attribute of a resource, e.g. /sensors/2/state/buttonevent
+	Operator Operator `json:"operator"`        // Comparison of the attribute
+	Value    string   `json:"value,omitempty"` // Value to compare, the bridge expects it as string
+}
+
+// Action of a rule, the address is relative e.g. /groups/0/action
+type Action struct {
+	Address string      `json:"address"` // Resource to change, e.g. /lights/1/state
+	Method  string      `json:"method"`  // POST, PUT or DELETE
+	Body    interface{} `json:"body"`    // Payload of the request, it is a map[string]interface{} when read from the bridge
+}
+
+// RuleParams is used to create or update a rule, nil fields are not changed.
+type RuleParams struct {
+	Name       *string     `json:"name,omitempty"`
+	Status     *string     `json:"status,omitempty"`
+	Recycle    *bool       `json:"recycle,omitempty"`
+	Conditions []Condition `json:"conditions,omitempty"`
+	Actions    []Action    `json:"actions,omitempty"`
+}
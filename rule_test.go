@@ -0,0 +1,236 @@
+package hue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	funk "github.com/thoas/go-funk"
+)
+
+var testRuleId = "1"
+
+// handleResources serves light, group and sensor fixtures which are used by rule validation
+func handleResources(t *testing.T, mux *http.ServeMux) {
+	for path, file := range map[string]string{
+		"/username/lights":  "testdata/Light_GetAll.json",
+		"/username/groups":  "testdata/Group_GetAll.json",
+		"/username/sensors": "testdata/Sensor_GetAll.json",
+	} {
+		bytes, _ := ioutil.ReadFile(file)
+		mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			w.Header().Set("Content-Type", "application/json")
+			fmt.Fprint(w, string(bytes))
+		})
+	}
+}
+
+func TestRuleService_GetAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Rule_GetAll.json")
+	mux.HandleFunc("/username/rules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Rules.GetAll(ctx)
+	if err != nil {
+		t.Errorf("Rule.GetAll returned error: %+v", err)
+	}
+
+	var result map[string]Rule
+	json.Unmarshal(bytes, &result)
+
+	for i, r := range result {
+		id, _ := strconv.Atoi(i)
+		r.ID = id
+		result[i] = r
+	}
+
+	want := funk.Values(result).([]Rule)
+
+	sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+	sort.Slice(want, func(i, j int) bool { return want[i].ID < want[j].ID })
+	if !cmp.Equal(got, want) {
+		t.Errorf("Rule.GetAll returned %+v, want %+v", got, want)
+	}
+}
+
+func TestRuleService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Rule_Get.json")
+	mux.HandleFunc("/username/rules/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Rules.Get(ctx, "2")
+	if err != nil {
+		t.Errorf("Rule.Get returned error: %+v", err)
+	}
+
+	want := &Rule{}
+	json.Unmarshal(bytes, want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Rule.Get returned %+v, want %+v", got, want)
+	}
+
+	assert.Equal(t, LocalTimeAddress.In(0, 22*time.Hour, 7*time.Hour), got.Conditions[1])
+}
+
+func TestRuleService_Create(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleResources(t, mux)
+
+	bytes, _ := ioutil.ReadFile("testdata/Rule_Create.json")
+	mux.HandleFunc("/username/rules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"address": "/sensors/5/state/buttonevent", "operator": "eq", "value": "1002"},
+			map[string]interface{}{"address": "/sensors/5/state/lastupdated", "operator": "dx"},
+		}, payload["conditions"])
+		assert.Equal(t, []interface{}{
+			map[string]interface{}{"address": "/groups/1/action", "method": "PUT", "body": map[string]interface{}{"on": true}},
+			map[string]interface{}{"address": "/groups/0/action", "method": "PUT", "body": map[string]interface{}{"scene": testSceneId}},
+		}, payload["actions"])
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Rules.Create(ctx, RuleParams{
+		Name: String("Dimmer switch on"),
+		Conditions: []Condition{
+			SensorStateAddress("5", "buttonevent").Eq(1002),
+			SensorStateAddress("5", "lastupdated").Dx(),
+		},
+		Actions: []Action{
+			GroupStateAction(testGroupId, SetStateParams{On: Bool(true)}),
+			GroupStateAction("0", SetStateParams{Scene: String(testSceneId)}),
+		},
+	})
+	if err != nil {
+		t.Errorf("Rule.Create returned error: %+v", err)
+	}
+
+	assert.Equal(t, "3", got)
+}
+
+func TestRuleService_Create_InvalidAddress(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleResources(t, mux)
+
+	mux.HandleFunc("/username/rules", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Rule with invalid address is sent to the bridge")
+	})
+
+	ctx := context.Background()
+	_, _, err := client.Rules.Create(ctx, RuleParams{
+		Name: String("Invalid"),
+		Conditions: []Condition{
+			SensorStateAddress("99", "buttonevent").Eq(1002),
+			LocalTimeAddress.In(Workdays, 8*time.Hour, 17*time.Hour),
+		},
+		Actions: []Action{
+			LightStateAction("1", SetStateParams{On: Bool(true)}),
+			GroupStateAction("42", SetStateParams{On: Bool(true)}),
+		},
+	})
+
+	assert.True(t, errors.Is(err, ErrInvalidValue))
+	assert.True(t, errors.Is(err, &Error{Type: ErrorTypeInvalidValue, Address: "/sensors/99/state/buttonevent"}))
+	assert.True(t, errors.Is(err, &Error{Type: ErrorTypeInvalidValue, Address: "/groups/42/action"}))
+	assert.False(t, errors.Is(err, &Error{Type: ErrorTypeInvalidValue, Address: "/lights/1/state"}))
+}
+
+func TestRuleService_Update(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Rule_Update.json")
+	mux.HandleFunc(fmt.Sprintf("/username/rules/%s", testRuleId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, map[string]interface{}{"status": RuleStatusDisabled}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	err := client.Rules.Disable(ctx, testRuleId)
+	if err != nil {
+		t.Errorf("Rule.Disable returned error: %+v", err)
+	}
+}
+
+func TestRuleService_Delete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Rule_Delete.json")
+	mux.HandleFunc(fmt.Sprintf("/username/rules/%s", testRuleId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, err := client.Rules.Delete(ctx, testRuleId)
+	if err != nil {
+		t.Errorf("Rule.Delete returned error: %+v", err)
+	}
+}
+
+func TestRuleAddress(t *testing.T) {
+	tests := []struct {
+		got  Condition
+		want Condition
+	}{
+		{SensorStateAddress("2", "presence").Eq(true), Condition{"/sensors/2/state/presence", OperatorEq, "true"}},
+		{SensorStateAddress("3", "lightlevel").Gt(16000), Condition{"/sensors/3/state/lightlevel", OperatorGt, "16000"}},
+		{SensorStateAddress("4", "temperature").Lt(1800), Condition{"/sensors/4/state/temperature", OperatorLt, "1800"}},
+		{SensorConfigAddress("2", "on").Dx(), Condition{"/sensors/2/config/on", OperatorDx, ""}},
+		{SensorStateAddress("2", "presence").Ddx(5 * time.Minute), Condition{"/sensors/2/state/presence", OperatorDdx, "PT00:05:00"}},
+		{LightStateAddress("1", "on").Stable(time.Minute), Condition{"/lights/1/state/on", OperatorStable, "PT00:01:00"}},
+		{GroupStateAddress("1", "any_on").NotStable(10 * time.Second), Condition{"/groups/1/state/any_on", OperatorNotStable, "PT00:00:10"}},
+		{LocalTimeAddress.In(Workdays, 8*time.Hour, 17*time.Hour), Condition{"/config/localtime", OperatorIn, "W124/T08:00:00/T17:00:00"}},
+		{LocalTimeAddress.NotIn(0, 22*time.Hour, 7*time.Hour), Condition{"/config/localtime", OperatorNotIn, "T22:00:00/T07:00:00"}},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.got)
+	}
+}
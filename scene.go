@@ -0,0 +1,180 @@
+package hue
+
+import (
+	"context"
+	"net/http"
+
+	funk "github.com/thoas/go-funk"
+)
+
+// SceneService has functions for scenes
+type SceneService service
+
+const sceneServiceName = "scenes"
+
+type createSceneRequest struct {
+	Name        string                    `json:"name"`
+	Type        string                    `json:"type"`
+	Group       string                    `json:"group,omitempty"`
+	Lights      []string                  `json:"lights,omitempty"`
+	Recycle     bool                      `json:"recycle"`
+	LightStates map[string]SetStateParams `json:"lightstates,omitempty"`
+}
+
+type updateSceneRequest struct {
+	Name            *string  `json:"name,omitempty"`
+	Lights          []string `json:"lights,omitempty"`
+	StoreLightState *bool    `json:"storelightstate,omitempty"`
+}
+
+func (s *SceneService) sceneServicePath(params ...string) string {
+	return s.client.path(sceneServiceName, params...)
+}
+
+// GetAll returns all scenes, light states are not included
+func (s *SceneService) GetAll(ctx context.Context) ([]Scene, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, s.sceneServicePath(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var scenes map[string]Scene
+	resp, err := s.client.do(ctx, req, &scenes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for k, sc := range scenes {
+		sc.ID = k
+		scenes[k] = sc
+	}
+
+	return funk.Values(scenes).([]Scene), resp, nil
+}
+
+// Get returns the scene by id together with the light states
+func (s *SceneService) Get(ctx context.Context, id string) (*Scene, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, s.sceneServicePath(id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	scene := new(Scene)
+	resp, err := s.client.do(ctx, req, scene)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return scene, resp, nil
+}
+
+// CreateLightScene creates a scene of given lights and returns id of the created scene
+// If lightStates is nil, the bridge stores the current state of the lights.
+func (s *SceneService) CreateLightScene(ctx context.Context, name string, lights []string, lightStates map[string]SetStateParams) (string, *Response, error) {
+	return s.create(ctx, &createSceneRequest{
+		Name:        name,
+		Type:        SceneTypeLight,
+		Lights:      lights,
+		LightStates: lightStates,
+	})
+}
+
+// CreateGroupScene creates a scene bound to the group and returns id of the created scene
+// If lightStates is nil, the bridge stores the current state of the lights in the group.
+func (s *SceneService) CreateGroupScene(ctx context.Context, name, group string, lightStates map[string]SetStateParams) (string, *Response, error) {
+	return s.create(ctx, &createSceneRequest{
+		Name:        name,
+		Type:        SceneTypeGroup,
+		Group:       group,
+		LightStates: lightStates,
+	})
+}
+
+func (s *SceneService) create(ctx context.Context, payload *createSceneRequest) (string, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPost, s.sceneServicePath(), payload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	if err != nil {
+		return "", resp, err
+	}
+
+	if len(apiResponses) == 0 {
+		return "", resp, ErrInvalidResponse
+	}
+
+	// Get first success message
+	id, ok := apiResponses[0].Success["id"].(string)
+	if !ok {
+		return "", resp, ErrInvalidResponse
+	}
+
+	return id, resp, nil
+}
+
+// Update updates name and lights of the scene
+// Lights can't be changed for a GroupScene.
+// If storeLightState is true, the current state of the lights is stored in the scene.
+func (s *SceneService) Update(ctx context.Context, id string, name *string, lights []string, storeLightState bool) (bool, *Response, error) {
+	payload := &updateSceneRequest{
+		Name:   name,
+		Lights: lights,
+	}
+	if storeLightState {
+		payload.StoreLightState = Bool(true)
+	}
+	req, err := s.client.newRequest(http.MethodPut, s.sceneServicePath(id), payload)
+	if err != nil {
+		return false, nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	if err != nil {
+		return false, resp, err
+	}
+
+	if len(apiResponses) == 0 {
+		return false, resp, ErrInvalidResponse
+	}
+
+	return true, resp, nil
+}
+
+// StoreLightState overwrites the light states of the scene with the current state of its lights
+func (s *SceneService) StoreLightState(ctx context.Context, id string) (*Response, error) {
+	_, resp, err := s.Update(ctx, id, nil, nil, true)
+	return resp, err
+}
+
+// SetLightState changes the stored state of a single light in the scene
+// It doesn't change the light itself, unless the scene is recalled.
+func (s *SceneService) SetLightState(ctx context.Context, id, lightID string, payload SetStateParams) ([]ApiResponse, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPut, s.sceneServicePath(id, "lightstates", lightID), payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	return apiResponses, resp, err
+}
+
+// Delete removes the scene
+// Locked scenes can't be deleted, the bridge returns an error.
+func (s *SceneService) Delete(ctx context.Context, id string) (*Response, error) {
+	req, err := s.client.newRequest(http.MethodDelete, s.sceneServicePath(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
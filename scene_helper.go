@@ -0,0 +1,10 @@
+package hue
+
+import "context"
+
+// Recall applies the stored light states of the scene through the group
+// Use group "0" to recall a LightScene on all its lights, a GroupScene should be recalled on its own group.
+func (s *SceneService) Recall(ctx context.Context, groupID, sceneID string) error {
+	_, _, err := s.client.Groups.SetState(ctx, groupID, SetStateParams{Scene: String(sceneID)})
+	return err
+}
@@ -0,0 +1,36 @@
+package hue
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSceneService_Recall(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Scene_Recall.json")
+	mux.HandleFunc(fmt.Sprintf("/username/groups/%s/action", testGroupId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload SetStateParams
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, testSceneId, *payload.Scene)
+		assert.Nil(t, payload.On)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	err := client.Scenes.Recall(ctx, testGroupId, testSceneId)
+	if err != nil {
+		t.Errorf("Scene.Recall returned error: %+v", err)
+	}
+}
@@ -0,0 +1,32 @@
+package hue
+
+// Scene types
+const (
+	SceneTypeLight = "LightScene" // Scene with an arbitrary list of lights, default type
+	SceneTypeGroup = "GroupScene" // Scene bound to a group, lights follow the group
+)
+
+// Scene struct that represents Philips Hue Scene
+//
+// Scene ids are strings generated by the bridge, e.g. "4e1c6b20e-on-0".
+type Scene struct {
+	ID          string                    `json:"-"`
+	Name        string                    `json:"name"`                  // Human readable name of the scene
+	Type        string                    `json:"type"`                  // LightScene or GroupScene
+	Group       string                    `json:"group,omitempty"`       // Group id of a GroupScene
+	Lights      []string                  `json:"lights"`                // The light ids which are in the scene
+	Owner       string                    `json:"owner"`                 // Whitelist user that created or last modified the scene
+	Recycle     bool                      `json:"recycle"`               // The bridge may delete the scene when it runs out of space
+	Locked      bool                      `json:"locked"`                // Locked scenes are used by a schedule or rule and can't be deleted
+	AppData     AppData                   `json:"appdata"`               // Data of the application which created the scene
+	Picture     string                    `json:"picture,omitempty"`     // Deprecated by Hue
+	LastUpdated string                    `json:"lastupdated"`           // Last time the scene was created or updated
+	Version     int                       `json:"version"`               // Version 1 scenes don't store their light states on the bridge
+	LightStates map[string]SetStateParams `json:"lightstates,omitempty"` // Light states by light id, only returned by Get
+}
+
+// AppData is free data an application can store with a resource
+type AppData struct {
+	Version int    `json:"version,omitempty"`
+	Data    string `json:"data,omitempty"`
+}
@@ -0,0 +1,207 @@
+package hue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	funk "github.com/thoas/go-funk"
+)
+
+var testSceneId = "3T2SvsxvwteNNys"
+
+func TestSceneService_GetAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Scene_GetAll.json")
+	mux.HandleFunc("/username/scenes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Scenes.GetAll(ctx)
+	if err != nil {
+		t.Errorf("Scene.GetAll returned error: %+v", err)
+	}
+
+	var result map[string]Scene
+	json.Unmarshal(bytes, &result)
+
+	for k, sc := range result {
+		sc.ID = k
+		result[k] = sc
+	}
+
+	want := funk.Values(result).([]Scene)
+
+	sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+	sort.Slice(want, func(i, j int) bool { return want[i].ID < want[j].ID })
+	if !cmp.Equal(got, want) {
+		t.Errorf("Scene.GetAll returned %+v, want %+v", got, want)
+	}
+}
+
+func TestSceneService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Scene_Get.json")
+	mux.HandleFunc(fmt.Sprintf("/username/scenes/%s", testSceneId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Scenes.Get(ctx, testSceneId)
+	if err != nil {
+		t.Errorf("Scene.Get returned error: %+v", err)
+	}
+
+	want := &Scene{}
+	json.Unmarshal(bytes, want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Scene.Get returned %+v, want %+v", got, want)
+	}
+
+	assert.Equal(t, SceneTypeGroup, got.Type)
+	assert.Equal(t, uint8(144), *got.LightStates["1"].Bri)
+	assert.Equal(t, uint16(366), *got.LightStates["2"].CT)
+}
+
+func TestSceneService_CreateLightScene(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Scene_Create.json")
+	mux.HandleFunc("/username/scenes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload createSceneRequest
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, "Reading", payload.Name)
+		assert.Equal(t, SceneTypeLight, payload.Type)
+		assert.Equal(t, []string{"1", "2"}, payload.Lights)
+		assert.Equal(t, uint8(200), *payload.LightStates["1"].Bri)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Scenes.CreateLightScene(ctx, "Reading", []string{"1", "2"}, map[string]SetStateParams{
+		"1": {On: Bool(true), Bri: UInt8(200)},
+		"2": {On: Bool(false)},
+	})
+	if err != nil {
+		t.Errorf("Scene.CreateLightScene returned error: %+v", err)
+	}
+
+	assert.Equal(t, "Abc123Def456Ghi", got)
+}
+
+func TestSceneService_CreateGroupScene(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Scene_Create.json")
+	mux.HandleFunc("/username/scenes", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, SceneTypeGroup, payload["type"])
+		assert.Equal(t, testGroupId, payload["group"])
+		assert.NotContains(t, payload, "lights")
+		assert.NotContains(t, payload, "lightstates")
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Scenes.CreateGroupScene(ctx, "Cozy dinner", testGroupId, nil)
+	if err != nil {
+		t.Errorf("Scene.CreateGroupScene returned error: %+v", err)
+	}
+
+	assert.Equal(t, "Abc123Def456Ghi", got)
+}
+
+func TestSceneService_Update(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Scene_Update.json")
+	mux.HandleFunc(fmt.Sprintf("/username/scenes/%s", testSceneId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload updateSceneRequest
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, "Dinner", *payload.Name)
+		assert.Equal(t, true, *payload.StoreLightState)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Scenes.Update(ctx, testSceneId, String("Dinner"), nil, true)
+	if err != nil {
+		t.Errorf("Scene.Update returned error: %+v", err)
+	}
+
+	assert.True(t, got)
+}
+
+func TestSceneService_SetLightState(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Scene_SetLightState.json")
+	mux.HandleFunc(fmt.Sprintf("/username/scenes/%s/lightstates/%s", testSceneId, testLightId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Scenes.SetLightState(ctx, testSceneId, testLightId, SetStateParams{On: Bool(true), Bri: UInt8(200)})
+	if err != nil {
+		t.Errorf("Scene.SetLightState returned error: %+v", err)
+	}
+
+	assert.Len(t, got, 2)
+}
+
+func TestSceneService_Delete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Scene_Delete.json")
+	mux.HandleFunc(fmt.Sprintf("/username/scenes/%s", testSceneId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, err := client.Scenes.Delete(ctx, testSceneId)
+	if err != nil {
+		t.Errorf("Scene.Delete returned error: %+v", err)
+	}
+}
@@ -0,0 +1,118 @@
+package hue
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	funk "github.com/thoas/go-funk"
+)
+
+// ScheduleService has functions for schedules
+type ScheduleService service
+
+const scheduleServiceName = "schedules"
+
+func (s *ScheduleService) scheduleServicePath(params ...string) string {
+	return s.client.path(scheduleServiceName, params...)
+}
+
+// GetAll returns all schedules
+func (s *ScheduleService) GetAll(ctx context.Context) ([]Schedule, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, s.scheduleServicePath(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var schedules map[string]Schedule
+	resp, err := s.client.do(ctx, req, &schedules)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for k, sc := range schedules {
+		id, _ := strconv.Atoi(k)
+		sc.ID = id
+		schedules[k] = sc
+	}
+
+	return funk.Values(schedules).([]Schedule), resp, nil
+}
+
+// Get returns the schedule by id
+func (s *ScheduleService) Get(ctx context.Context, id string) (*Schedule, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, s.scheduleServicePath(id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schedule := new(Schedule)
+	resp, err := s.client.do(ctx, req, schedule)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return schedule, resp, nil
+}
+
+// Create creates a schedule and returns id of the created schedule
+// Command and LocalTime are required.
+func (s *ScheduleService) Create(ctx context.Context, payload ScheduleParams) (string, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPost, s.scheduleServicePath(), payload)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	if err != nil {
+		return "", resp, err
+	}
+
+	if len(apiResponses) == 0 {
+		return "", resp, ErrInvalidResponse
+	}
+
+	// Get first success message
+	id, ok := apiResponses[0].Success["id"].(string)
+	if !ok {
+		return "", resp, ErrInvalidResponse
+	}
+
+	return id, resp, nil
+}
+
+// Update updates the schedule by id
+func (s *ScheduleService) Update(ctx context.Context, id string, payload ScheduleParams) (bool, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPut, s.scheduleServicePath(id), payload)
+	if err != nil {
+		return false, nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	if err != nil {
+		return false, resp, err
+	}
+
+	if len(apiResponses) == 0 {
+		return false, resp, ErrInvalidResponse
+	}
+
+	return true, resp, nil
+}
+
+// Delete removes the schedule
+func (s *ScheduleService) Delete(ctx context.Context, id string) (*Response, error) {
+	req, err := s.client.newRequest(http.MethodDelete, s.scheduleServicePath(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
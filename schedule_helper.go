@@ -0,0 +1,15 @@
+package hue
+
+import "context"
+
+// Enable sets the status of the schedule as enabled
+func (s *ScheduleService) Enable(ctx context.Context, id string) error {
+	_, _, err := s.Update(ctx, id, ScheduleParams{Status: String(ScheduleStatusEnabled)})
+	return err
+}
+
+// Disable sets the status of the schedule as disabled
+func (s *ScheduleService) Disable(ctx context.Context, id string) error {
+	_, _, err := s.Update(ctx, id, ScheduleParams{Status: String(ScheduleStatusDisabled)})
+	return err
+}
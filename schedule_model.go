@@ -0,0 +1,40 @@
+package hue
+
+// Schedule statuses
+const (
+	ScheduleStatusEnabled  = "enabled"
+	ScheduleStatusDisabled = "disabled"
+)
+
+// Schedule struct that represents Philips Hue Schedule
+type Schedule struct {
+	ID          int         `json:"-"`
+	Name        string      `json:"name"`                // Name of the schedule
+	Description string      `json:"description"`         // Description of the schedule
+	Command     Command     `json:"command"`             // Command to execute when the schedule triggers
+	LocalTime   TimePattern `json:"localtime"`           // Time when the schedule triggers, in local time of the bridge
+	Time        string      `json:"time,omitempty"`      // Deprecated by Hue, same as LocalTime but in UTC
+	Created     string      `json:"created"`             // Creation time of the schedule
+	Status      string      `json:"status"`              // enabled or disabled
+	AutoDelete  bool        `json:"autodelete"`          // Delete the schedule after it expires
+	StartTime   string      `json:"starttime,omitempty"` // Start time of a timer, in UTC
+	Recycle     bool        `json:"recycle"`             // The bridge may delete the schedule when it runs out of space
+}
+
+// Command is a request the bridge executes on its own API, e.g. when a schedule triggers.
+type Command struct {
+	Address string      `json:"address"` // Path of the resource, e.g. /api/<username>/lights/1/state
+	Method  string      `json:"method"`  // POST, PUT or DELETE
+	Body    interface{} `json:"body"`    // Payload of the request, it is a map[string]interface{} when read from the bridge
+}
+
+// ScheduleParams is used to create or update a schedule, nil fields are not changed.
+type ScheduleParams struct {
+	Name        *string      `json:"name,omitempty"`
+	Description *string      `json:"description,omitempty"`
+	Command     *Command     `json:"command,omitempty"`
+	LocalTime   *TimePattern `json:"localtime,omitempty"`
+	Status      *string      `json:"status,omitempty"`
+	AutoDelete  *bool        `json:"autodelete,omitempty"`
+	Recycle     *bool        `json:"recycle,omitempty"`
+}
@@ -0,0 +1,201 @@
+package hue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	funk "github.com/thoas/go-funk"
+)
+
+var testScheduleId = "1"
+
+func TestScheduleService_GetAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Schedule_GetAll.json")
+	mux.HandleFunc("/username/schedules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Schedules.GetAll(ctx)
+	if err != nil {
+		t.Errorf("Schedule.GetAll returned error: %+v", err)
+	}
+
+	var result map[string]Schedule
+	json.Unmarshal(bytes, &result)
+
+	for i, s := range result {
+		id, _ := strconv.Atoi(i)
+		s.ID = id
+		result[i] = s
+	}
+
+	want := funk.Values(result).([]Schedule)
+
+	sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+	sort.Slice(want, func(i, j int) bool { return want[i].ID < want[j].ID })
+	if !cmp.Equal(got, want) {
+		t.Errorf("Schedule.GetAll returned %+v, want %+v", got, want)
+	}
+
+	assert.Equal(t, RecurringTime(Workdays, 7*time.Hour), got[0].LocalTime)
+	assert.Equal(t, Timer(10*time.Minute), got[1].LocalTime)
+}
+
+func TestScheduleService_GetAll_UnsupportedTimePattern(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/username/schedules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{
+			"1": {"name": "Wake up", "localtime": "W124/T07:00:00", "status": "enabled"},
+			"2": {"name": "Office hours", "localtime": "W127/T08:00:00/T09:00:00", "status": "enabled"}
+		}`)
+	})
+
+	got, _, err := client.Schedules.GetAll(context.Background())
+	if err != nil {
+		t.Fatalf("Schedule.GetAll returned error: %+v", err)
+	}
+
+	sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+	if assert.Len(t, got, 2) {
+		assert.Equal(t, RecurringTime(Workdays, 7*time.Hour), got[0].LocalTime)
+		assert.Equal(t, TimePattern{Raw: "W127/T08:00:00/T09:00:00"}, got[1].LocalTime)
+	}
+}
+
+func TestScheduleService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Schedule_Get.json")
+	mux.HandleFunc(fmt.Sprintf("/username/schedules/%s", testScheduleId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Schedules.Get(ctx, testScheduleId)
+	if err != nil {
+		t.Errorf("Schedule.Get returned error: %+v", err)
+	}
+
+	want := &Schedule{}
+	json.Unmarshal(bytes, want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Schedule.Get returned %+v, want %+v", got, want)
+	}
+
+	assert.Equal(t, RecurringTime(Everyday, 20*time.Hour).Randomized(30*time.Minute), got.LocalTime)
+	assert.Equal(t, map[string]interface{}{"scene": "3T2SvsxvwteNNys"}, got.Command.Body)
+}
+
+func TestScheduleService_Create(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Schedule_Create.json")
+	mux.HandleFunc("/username/schedules", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, "Wake up", payload["name"])
+		assert.Equal(t, "W124/T07:00:00", payload["localtime"])
+		assert.Equal(t, map[string]interface{}{
+			"address": "/api/username/groups/1/action",
+			"method":  "PUT",
+			"body":    map[string]interface{}{"on": true, "bri": float64(254)},
+		}, payload["command"])
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	localTime := RecurringTime(Workdays, 7*time.Hour)
+	got, _, err := client.Schedules.Create(ctx, ScheduleParams{
+		Name:      String("Wake up"),
+		Command:   client.Groups.StateCommand(testGroupId, SetStateParams{On: Bool(true), Bri: UInt8(254)}),
+		LocalTime: &localTime,
+	})
+	if err != nil {
+		t.Errorf("Schedule.Create returned error: %+v", err)
+	}
+
+	assert.Equal(t, "3", got)
+}
+
+func TestScheduleService_Update(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Schedule_Update.json")
+	mux.HandleFunc(fmt.Sprintf("/username/schedules/%s", testScheduleId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, map[string]interface{}{"status": ScheduleStatusDisabled}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	err := client.Schedules.Disable(ctx, testScheduleId)
+	if err != nil {
+		t.Errorf("Schedule.Disable returned error: %+v", err)
+	}
+}
+
+func TestScheduleService_Delete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Schedule_Delete.json")
+	mux.HandleFunc(fmt.Sprintf("/username/schedules/%s", testScheduleId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, err := client.Schedules.Delete(ctx, testScheduleId)
+	if err != nil {
+		t.Errorf("Schedule.Delete returned error: %+v", err)
+	}
+}
+
+func TestLightService_StateCommand(t *testing.T) {
+	client, _, _, teardown := setup()
+	defer teardown()
+
+	got := client.Lights.StateCommand(testLightId, SetStateParams{On: Bool(false)})
+
+	assert.Equal(t, "/api/username/lights/1/state", got.Address)
+	assert.Equal(t, http.MethodPut, got.Method)
+	assert.Equal(t, SetStateParams{On: Bool(false)}, got.Body)
+}
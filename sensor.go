@@ -0,0 +1,191 @@
+package hue
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	funk "github.com/thoas/go-funk"
+)
+
+// SensorService has functions for sensors
+type SensorService service
+
+const sensorServiceName = "sensors"
+
+func (s *SensorService) sensorServicePath(params ...string) string {
+	return s.client.path(sensorServiceName, params...)
+}
+
+// GetAll returns a list of all sensors that have been added to the bridge.
+func (s *SensorService) GetAll(ctx context.Context) ([]Sensor, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, s.sensorServicePath(), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var sensors map[string]Sensor
+	resp, err := s.client.do(ctx, req, &sensors)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	for k, sn := range sensors {
+		id, _ := strconv.Atoi(k)
+		sn.ID = id
+		sensors[k] = sn
+	}
+
+	return funk.Values(sensors).([]Sensor), resp, nil
+}
+
+// Get returns sensor by id
+func (s *SensorService) Get(ctx context.Context, id string) (*Sensor, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, s.sensorServicePath(id), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	sensor := new(Sensor)
+	resp, err := s.client.do(ctx, req, sensor)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return sensor, resp, nil
+}
+
+// GetNew returns a list of sensors that were discovered the last time a search for new sensors was performed.
+func (s *SensorService) GetNew(ctx context.Context) (map[string]string, *Response, error) {
+	req, err := s.client.newRequest(http.MethodGet, s.sensorServicePath("new"), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var parsed map[string]interface{}
+	resp, err := s.client.do(ctx, req, &parsed)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	sensors := make(map[string]string)
+	for k, sn := range parsed {
+		if k == "lastscan" {
+			// Skip lastscan
+		} else if m, ok := sn.(map[string]interface{}); ok {
+			sensors[k], _ = m["name"].(string)
+		}
+	}
+
+	return sensors, resp, nil
+}
+
+// Search starts searching for new sensors
+// The bridge will open the network for 40s.
+func (s *SensorService) Search(ctx context.Context) (*Response, error) {
+	req, err := s.client.newRequest(http.MethodPost, s.sensorServicePath(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(apiResponses) == 0 {
+		return resp, ErrInvalidResponse
+	}
+
+	return resp, nil
+}
+
+// Create adds a CLIP sensor to the bridge and returns id of the created sensor
+// Only CLIP sensor types can be created, e.g. CLIPGenericFlag or CLIPPresence.
+func (s *SensorService) Create(ctx context.Context, sensor Sensor) (string, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPost, s.sensorServicePath(), sensor)
+	if err != nil {
+		return "", nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	if err != nil {
+		return "", resp, err
+	}
+
+	if len(apiResponses) == 0 {
+		return "", resp, ErrInvalidResponse
+	}
+
+	// Get first success message
+	id, ok := apiResponses[0].Success["id"].(string)
+	if !ok {
+		return "", resp, ErrInvalidResponse
+	}
+
+	return id, resp, nil
+}
+
+// Rename sensors
+func (s *SensorService) Rename(ctx context.Context, id, name string) (*Response, error) {
+	var payload = struct {
+		Name string `json:"name"`
+	}{name}
+	req, err := s.client.newRequest(http.MethodPut, s.sensorServicePath(id), payload)
+	if err != nil {
+		return nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	if err != nil {
+		return resp, err
+	}
+
+	if len(apiResponses) == 0 {
+		return resp, ErrInvalidResponse
+	}
+
+	return resp, nil
+}
+
+// UpdateConfig changes the writable config attributes of the sensor, e.g. on, sensitivity or sunriseoffset.
+func (s *SensorService) UpdateConfig(ctx context.Context, id string, payload SensorConfig) ([]ApiResponse, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPut, s.sensorServicePath(id, "config"), payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	return apiResponses, resp, err
+}
+
+// SetState changes the state of the sensor, it is only allowed for CLIP sensors.
+func (s *SensorService) SetState(ctx context.Context, id string, payload SensorState) ([]ApiResponse, *Response, error) {
+	req, err := s.client.newRequest(http.MethodPut, s.sensorServicePath(id, "state"), payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var apiResponses []ApiResponse
+	resp, err := s.client.do(ctx, req, &apiResponses)
+	return apiResponses, resp, err
+}
+
+// Delete a sensor from the bridge.
+func (s *SensorService) Delete(ctx context.Context, id string) (*Response, error) {
+	req, err := s.client.newRequest(http.MethodDelete, s.sensorServicePath(id), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.do(ctx, req, nil)
+	if err != nil {
+		return resp, err
+	}
+
+	return resp, nil
+}
@@ -0,0 +1,111 @@
+package hue
+
+func (s *Sensor) GetID() int {
+	if s == nil {
+		return 0
+	}
+	return s.ID
+}
+
+func (s *Sensor) GetName() string {
+	if s == nil {
+		return ""
+	}
+	return s.Name
+}
+
+func (s *Sensor) GetType() string {
+	if s == nil {
+		return ""
+	}
+	return s.Type
+}
+
+// State
+
+func (s *Sensor) GetLastUpdated() string {
+	if s == nil {
+		return ""
+	}
+	return s.State.LastUpdated
+}
+
+func (s *Sensor) IsPresence() bool {
+	if s == nil || s.State.Presence == nil {
+		return false
+	}
+	return *s.State.Presence
+}
+
+func (s *Sensor) GetLightLevel() int {
+	if s == nil || s.State.LightLevel == nil {
+		return 0
+	}
+	return *s.State.LightLevel
+}
+
+func (s *Sensor) IsDark() bool {
+	if s == nil || s.State.Dark == nil {
+		return false
+	}
+	return *s.State.Dark
+}
+
+func (s *Sensor) IsDaylight() bool {
+	if s == nil || s.State.Daylight == nil {
+		return false
+	}
+	return *s.State.Daylight
+}
+
+// GetTemperature returns the temperature in degrees celsius
+func (s *Sensor) GetTemperature() float64 {
+	if s == nil || s.State.Temperature == nil {
+		return 0
+	}
+	return float64(*s.State.Temperature) / 100
+}
+
+func (s *Sensor) GetButtonEvent() int {
+	if s == nil || s.State.ButtonEvent == nil {
+		return 0
+	}
+	return *s.State.ButtonEvent
+}
+
+func (s *Sensor) GetFlag() bool {
+	if s == nil || s.State.Flag == nil {
+		return false
+	}
+	return *s.State.Flag
+}
+
+func (s *Sensor) GetStatus() int {
+	if s == nil || s.State.Status == nil {
+		return 0
+	}
+	return *s.State.Status
+}
+
+// Config
+
+func (s *Sensor) IsOn() bool {
+	if s == nil || s.Config.On == nil {
+		return false
+	}
+	return *s.Config.On
+}
+
+func (s *Sensor) IsReachable() bool {
+	if s == nil || s.Config.Reachable == nil {
+		return false
+	}
+	return *s.Config.Reachable
+}
+
+func (s *Sensor) GetBattery() int {
+	if s == nil || s.Config.Battery == nil {
+		return 0
+	}
+	return *s.Config.Battery
+}
@@ -0,0 +1,111 @@
+package hue
+
+// AsPresence returns the sensor as PresenceSensor, ok is false for other sensor types
+func (s *Sensor) AsPresence() (*PresenceSensor, bool) {
+	if !s.isType(SensorTypeZLLPresence, SensorTypeCLIPPresence) {
+		return nil, false
+	}
+	return &PresenceSensor{
+		Sensor:         s,
+		Presence:       s.IsPresence(),
+		Sensitivity:    intValue(s.Config.Sensitivity),
+		SensitivityMax: intValue(s.Config.SensitivityMax),
+	}, true
+}
+
+// AsLightLevel returns the sensor as LightLevelSensor, ok is false for other sensor types
+func (s *Sensor) AsLightLevel() (*LightLevelSensor, bool) {
+	if !s.isType(SensorTypeZLLLightLevel, SensorTypeCLIPLightLevel) {
+		return nil, false
+	}
+	return &LightLevelSensor{
+		Sensor:      s,
+		LightLevel:  s.GetLightLevel(),
+		Dark:        s.IsDark(),
+		Daylight:    s.IsDaylight(),
+		TholdDark:   intValue(s.Config.TholdDark),
+		TholdOffset: intValue(s.Config.TholdOffset),
+	}, true
+}
+
+// AsTemperature returns the sensor as TemperatureSensor, ok is false for other sensor types
+func (s *Sensor) AsTemperature() (*TemperatureSensor, bool) {
+	if !s.isType(SensorTypeZLLTemperature, SensorTypeCLIPTemperature) {
+		return nil, false
+	}
+	return &TemperatureSensor{Sensor: s, Temperature: s.GetTemperature()}, true
+}
+
+// AsHumidity returns the sensor as HumiditySensor, ok is false for other sensor types
+func (s *Sensor) AsHumidity() (*HumiditySensor, bool) {
+	if !s.isType(SensorTypeCLIPHumidity) {
+		return nil, false
+	}
+	return &HumiditySensor{Sensor: s, Humidity: float64(intValue(s.State.Humidity)) / 100}, true
+}
+
+// AsSwitch returns the sensor as SwitchSensor, ok is false for other sensor types
+func (s *Sensor) AsSwitch() (*SwitchSensor, bool) {
+	if !s.isType(SensorTypeZLLSwitch, SensorTypeZGPSwitch, SensorTypeCLIPSwitch) {
+		return nil, false
+	}
+	return &SwitchSensor{Sensor: s, ButtonEvent: s.GetButtonEvent()}, true
+}
+
+// AsOpenClose returns the sensor as OpenCloseSensor, ok is false for other sensor types
+func (s *Sensor) AsOpenClose() (*OpenCloseSensor, bool) {
+	if !s.isType(SensorTypeCLIPOpenClose) {
+		return nil, false
+	}
+	return &OpenCloseSensor{Sensor: s, Open: s.State.Open != nil && *s.State.Open}, true
+}
+
+// AsDaylight returns the sensor as DaylightSensor, ok is false for other sensor types
+func (s *Sensor) AsDaylight() (*DaylightSensor, bool) {
+	if !s.isType(SensorTypeDaylight) {
+		return nil, false
+	}
+	return &DaylightSensor{
+		Sensor:        s,
+		Daylight:      s.IsDaylight(),
+		Configured:    s.Config.Configured != nil && *s.Config.Configured,
+		SunriseOffset: intValue(s.Config.SunriseOffset),
+		SunsetOffset:  intValue(s.Config.SunsetOffset),
+	}, true
+}
+
+// AsGenericFlag returns the sensor as GenericFlagSensor, ok is false for other sensor types
+func (s *Sensor) AsGenericFlag() (*GenericFlagSensor, bool) {
+	if !s.isType(SensorTypeCLIPGenericFlag) {
+		return nil, false
+	}
+	return &GenericFlagSensor{Sensor: s, Flag: s.GetFlag()}, true
+}
+
+// AsGenericStatus returns the sensor as GenericStatusSensor, ok is false for other sensor types
+func (s *Sensor) AsGenericStatus() (*GenericStatusSensor, bool) {
+	if !s.isType(SensorTypeCLIPGenericStatus) {
+		return nil, false
+	}
+	return &GenericStatusSensor{Sensor: s, Status: s.GetStatus()}, true
+}
+
+// isType reports whether the sensor is one of the types
+func (s *Sensor) isType(types ...string) bool {
+	if s == nil {
+		return false
+	}
+	for _, t := range types {
+		if s.Type == t {
+			return true
+		}
+	}
+	return false
+}
+
+func intValue(v *int) int {
+	if v == nil {
+		return 0
+	}
+	return *v
+}
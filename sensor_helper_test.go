@@ -0,0 +1,140 @@
+package hue
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testSensors(t *testing.T) map[string]*Sensor {
+	bytes, err := ioutil.ReadFile("testdata/Sensor_GetAll.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var sensors map[string]*Sensor
+	if err := json.Unmarshal(bytes, &sensors); err != nil {
+		t.Fatal(err)
+	}
+	return sensors
+}
+
+func TestSensor_AsDaylight(t *testing.T) {
+	sensors := testSensors(t)
+
+	got, ok := sensors["1"].AsDaylight()
+	if assert.True(t, ok) {
+		assert.Equal(t, &DaylightSensor{Sensor: sensors["1"], Daylight: false, Configured: true, SunriseOffset: 30, SunsetOffset: -30}, got)
+	}
+
+	_, ok = sensors["2"].AsDaylight()
+	assert.False(t, ok)
+}
+
+func TestSensor_AsPresence(t *testing.T) {
+	sensors := testSensors(t)
+
+	got, ok := sensors["2"].AsPresence()
+	if assert.True(t, ok) {
+		assert.Equal(t, &PresenceSensor{Sensor: sensors["2"], Presence: false, Sensitivity: 2, SensitivityMax: 2}, got)
+		assert.Equal(t, "Hallway sensor", got.Name)
+	}
+
+	clip := &Sensor{Type: SensorTypeCLIPPresence, State: SensorState{Presence: Bool(true)}}
+	got, ok = clip.AsPresence()
+	if assert.True(t, ok) {
+		assert.True(t, got.Presence)
+	}
+
+	_, ok = sensors["3"].AsPresence()
+	assert.False(t, ok)
+}
+
+func TestSensor_AsLightLevel(t *testing.T) {
+	sensors := testSensors(t)
+
+	got, ok := sensors["3"].AsLightLevel()
+	if assert.True(t, ok) {
+		assert.Equal(t, &LightLevelSensor{Sensor: sensors["3"], LightLevel: 13172, Dark: true, Daylight: false, TholdDark: 16000, TholdOffset: 7000}, got)
+	}
+
+	_, ok = sensors["4"].AsLightLevel()
+	assert.False(t, ok)
+}
+
+func TestSensor_AsTemperature(t *testing.T) {
+	sensors := testSensors(t)
+
+	got, ok := sensors["4"].AsTemperature()
+	if assert.True(t, ok) {
+		assert.Equal(t, 21.34, got.Temperature)
+	}
+
+	_, ok = sensors["5"].AsTemperature()
+	assert.False(t, ok)
+}
+
+func TestSensor_AsSwitch(t *testing.T) {
+	sensors := testSensors(t)
+
+	got, ok := sensors["5"].AsSwitch()
+	if assert.True(t, ok) {
+		assert.Equal(t, &SwitchSensor{Sensor: sensors["5"], ButtonEvent: 1002}, got)
+	}
+
+	got, ok = sensors["6"].AsSwitch()
+	if assert.True(t, ok) {
+		assert.Equal(t, 34, got.ButtonEvent)
+	}
+
+	_, ok = sensors["7"].AsSwitch()
+	assert.False(t, ok)
+}
+
+func TestSensor_AsGenericFlag(t *testing.T) {
+	sensors := testSensors(t)
+
+	got, ok := sensors["7"].AsGenericFlag()
+	if assert.True(t, ok) {
+		assert.Equal(t, &GenericFlagSensor{Sensor: sensors["7"], Flag: true}, got)
+	}
+
+	_, ok = sensors["8"].AsGenericFlag()
+	assert.False(t, ok)
+}
+
+func TestSensor_AsGenericStatus(t *testing.T) {
+	sensors := testSensors(t)
+
+	got, ok := sensors["8"].AsGenericStatus()
+	if assert.True(t, ok) {
+		assert.Equal(t, &GenericStatusSensor{Sensor: sensors["8"], Status: 1}, got)
+	}
+
+	_, ok = sensors["7"].AsGenericStatus()
+	assert.False(t, ok)
+}
+
+func TestSensor_AsHumidity(t *testing.T) {
+	sensor := &Sensor{Type: SensorTypeCLIPHumidity, State: SensorState{Humidity: Int(4550)}}
+
+	got, ok := sensor.AsHumidity()
+	if assert.True(t, ok) {
+		assert.Equal(t, 45.5, got.Humidity)
+	}
+}
+
+func TestSensor_AsOpenClose(t *testing.T) {
+	sensor := &Sensor{Type: SensorTypeCLIPOpenClose, State: SensorState{Open: Bool(true)}}
+
+	got, ok := sensor.AsOpenClose()
+	if assert.True(t, ok) {
+		assert.True(t, got.Open)
+	}
+
+	var missing *Sensor
+	_, ok = missing.AsOpenClose()
+	assert.False(t, ok)
+}
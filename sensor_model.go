@@ -0,0 +1,149 @@
+package hue
+
+// Sensor types supported by the bridge
+const (
+	SensorTypeZLLPresence       = "ZLLPresence"    // Motion sensor
+	SensorTypeZLLLightLevel     = "ZLLLightLevel"  // Light level part of the motion sensor
+	SensorTypeZLLTemperature    = "ZLLTemperature" // Temperature part of the motion sensor
+	SensorTypeZLLSwitch         = "ZLLSwitch"      // Dimmer switch
+	SensorTypeZGPSwitch         = "ZGPSwitch"      // Hue tap
+	SensorTypeDaylight          = "Daylight"       // Built-in daylight sensor of the bridge
+	SensorTypeCLIPGenericFlag   = "CLIPGenericFlag"
+	SensorTypeCLIPGenericStatus = "CLIPGenericStatus"
+	SensorTypeCLIPPresence      = "CLIPPresence"
+	SensorTypeCLIPLightLevel    = "CLIPLightLevel"
+	SensorTypeCLIPTemperature   = "CLIPTemperature"
+	SensorTypeCLIPHumidity      = "CLIPHumidity"
+	SensorTypeCLIPOpenClose     = "CLIPOpenClose"
+	SensorTypeCLIPSwitch        = "CLIPSwitch"
+)
+
+// Sensor struct that represents Philips Hue Sensor
+//
+// State and Config only contain the attributes of the sensor type, the others are nil.
+type Sensor struct {
+	ID               int                 `json:"-"`
+	Name             string              `json:"name"`
+	Type             string              `json:"type"`
+	ModelId          string              `json:"modelid"`
+	ManufacturerName string              `json:"manufacturername"`
+	ProductName      string              `json:"productname,omitempty"`
+	SWVersion        string              `json:"swversion"`
+	UniqueId         string              `json:"uniqueid,omitempty"`
+	Recycle          bool                `json:"recycle,omitempty"`
+	State            SensorState         `json:"state"`
+	Config           SensorConfig        `json:"config"`
+	Capabilities     *SensorCapabilities `json:"capabilities,omitempty"`
+	SWUpdate         *SWUpdate           `json:"swupdate,omitempty"`
+}
+
+// SensorState is the state of the sensor, it is also used to update CLIP sensors.
+type SensorState struct {
+	LastUpdated string `json:"lastupdated,omitempty"` // Last time the state changed, "none" if never
+
+	Presence    *bool `json:"presence,omitempty"`    // ZLLPresence, CLIPPresence
+	LightLevel  *int  `json:"lightlevel,omitempty"`  // ZLLLightLevel, CLIPLightLevel. 10000 log10(lux) + 1
+	Dark        *bool `json:"dark,omitempty"`        // ZLLLightLevel, CLIPLightLevel
+	Daylight    *bool `json:"daylight,omitempty"`    // ZLLLightLevel, CLIPLightLevel, Daylight
+	Temperature *int  `json:"temperature,omitempty"` // ZLLTemperature, CLIPTemperature. In 0.01 degrees celsius
+	Humidity    *int  `json:"humidity,omitempty"`    // CLIPHumidity. In 0.01 percent
+	ButtonEvent *int  `json:"buttonevent,omitempty"` // ZLLSwitch, ZGPSwitch, CLIPSwitch
+	Open        *bool `json:"open,omitempty"`        // CLIPOpenClose
+	Flag        *bool `json:"flag,omitempty"`        // CLIPGenericFlag
+	Status      *int  `json:"status,omitempty"`      // CLIPGenericStatus
+}
+
+// SensorConfig is the configuration of the sensor, writable attributes can be changed with UpdateConfig.
+type SensorConfig struct {
+	On            *bool    `json:"on,omitempty"`
+	Reachable     *bool    `json:"reachable,omitempty"`
+	Battery       *int     `json:"battery,omitempty"` // Battery level in percent
+	Alert         *string  `json:"alert,omitempty"`
+	LEDIndication *bool    `json:"ledindication,omitempty"`
+	UserTest      *bool    `json:"usertest,omitempty"`
+	Pending       []string `json:"pending,omitempty"`
+	URL           *string  `json:"url,omitempty"` // CLIP sensors
+
+	Sensitivity    *int `json:"sensitivity,omitempty"`    // ZLLPresence
+	SensitivityMax *int `json:"sensitivitymax,omitempty"` // ZLLPresence
+
+	TholdDark   *int `json:"tholddark,omitempty"`   // ZLLLightLevel
+	TholdOffset *int `json:"tholdoffset,omitempty"` // ZLLLightLevel
+
+	Configured    *bool   `json:"configured,omitempty"`    // Daylight
+	Long          *string `json:"long,omitempty"`          // Daylight, write only e.g. "004.8890E"
+	Lat           *string `json:"lat,omitempty"`           // Daylight, write only e.g. "052.3700N"
+	SunriseOffset *int    `json:"sunriseoffset,omitempty"` // Daylight, in minutes
+	SunsetOffset  *int    `json:"sunsetoffset,omitempty"`  // Daylight, in minutes
+}
+
+type SensorCapabilities struct {
+	Certified bool `json:"certified"`
+	Primary   bool `json:"primary"`
+}
+
+// PresenceSensor is a ZLLPresence or CLIPPresence sensor, see Sensor.AsPresence
+type PresenceSensor struct {
+	*Sensor
+	Presence       bool
+	Sensitivity    int // ZLLPresence only
+	SensitivityMax int // ZLLPresence only
+}
+
+// LightLevelSensor is a ZLLLightLevel or CLIPLightLevel sensor, see Sensor.AsLightLevel
+type LightLevelSensor struct {
+	*Sensor
+	LightLevel  int // 10000 log10(lux) + 1
+	Dark        bool
+	Daylight    bool
+	TholdDark   int // ZLLLightLevel only
+	TholdOffset int // ZLLLightLevel only
+}
+
+// TemperatureSensor is a ZLLTemperature or CLIPTemperature sensor, see Sensor.AsTemperature
+type TemperatureSensor struct {
+	*Sensor
+	Temperature float64 // In degrees celsius
+}
+
+// HumiditySensor is a CLIPHumidity sensor, see Sensor.AsHumidity
+type HumiditySensor struct {
+	*Sensor
+	Humidity float64 // In percent
+}
+
+// SwitchSensor is a ZLLSwitch, ZGPSwitch or CLIPSwitch sensor, see Sensor.AsSwitch
+//
+// The ButtonEvent of a dimmer switch is the button times 1000 plus the event,
+// e.g. 1002 is the short release of the on button. A Hue tap reports 34, 16, 17 or 18.
+type SwitchSensor struct {
+	*Sensor
+	ButtonEvent int
+}
+
+// OpenCloseSensor is a CLIPOpenClose sensor, see Sensor.AsOpenClose
+type OpenCloseSensor struct {
+	*Sensor
+	Open bool
+}
+
+// DaylightSensor is the built-in Daylight sensor of the bridge, see Sensor.AsDaylight
+type DaylightSensor struct {
+	*Sensor
+	Daylight      bool
+	Configured    bool // Whether the location of the bridge is set
+	SunriseOffset int  // In minutes
+	SunsetOffset  int  // In minutes
+}
+
+// GenericFlagSensor is a CLIPGenericFlag sensor, see Sensor.AsGenericFlag
+type GenericFlagSensor struct {
+	*Sensor
+	Flag bool
+}
+
+// GenericStatusSensor is a CLIPGenericStatus sensor, see Sensor.AsGenericStatus
+type GenericStatusSensor struct {
+	*Sensor
+	Status int
+}
@@ -0,0 +1,266 @@
+package hue
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stretchr/testify/assert"
+	funk "github.com/thoas/go-funk"
+)
+
+func TestSensorService_GetAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Sensor_GetAll.json")
+	mux.HandleFunc("/username/sensors", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, resp, err := client.Sensors.GetAll(ctx)
+	if err != nil {
+		t.Errorf("Sensors.GetAll returned error: %+v", err)
+	}
+
+	assert.Equal(t, http.StatusOK, resp.Response.StatusCode)
+
+	var result map[string]Sensor
+	json.Unmarshal(bytes, &result)
+
+	for i, s := range result {
+		id, _ := strconv.Atoi(i)
+		s.ID = id
+		result[i] = s
+	}
+
+	want := funk.Values(result).([]Sensor)
+
+	sort.Slice(got, func(i, j int) bool { return got[i].ID < got[j].ID })
+	sort.Slice(want, func(i, j int) bool { return want[i].ID < want[j].ID })
+	if !cmp.Equal(got, want) {
+		t.Errorf("Sensors.GetAll returned %+v, want %+v", got, want)
+	}
+
+	assert.Len(t, got, 8)
+	assert.Equal(t, SensorTypeDaylight, got[0].GetType())
+	assert.False(t, got[0].IsDaylight())
+	assert.False(t, got[1].IsPresence())
+	assert.Equal(t, 87, got[1].GetBattery())
+	assert.Equal(t, 13172, got[2].GetLightLevel())
+	assert.True(t, got[2].IsDark())
+	assert.Equal(t, 21.34, got[3].GetTemperature())
+	assert.Equal(t, 1002, got[4].GetButtonEvent())
+	assert.Equal(t, 34, got[5].GetButtonEvent())
+	assert.True(t, got[6].GetFlag())
+	assert.Equal(t, 1, got[7].GetStatus())
+}
+
+func TestSensorService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Sensor_Get.json")
+	mux.HandleFunc("/username/sensors/5", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, resp, err := client.Sensors.Get(ctx, "5")
+	if err != nil {
+		t.Errorf("Sensor.Get returned error: %+v", err)
+	}
+
+	assert.Equal(t, http.StatusOK, resp.Response.StatusCode)
+
+	want := &Sensor{}
+	json.Unmarshal(bytes, want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Sensor.Get returned %+v, want %+v", got, want)
+	}
+}
+
+func TestSensorService_GetNew(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Sensor_GetNew.json")
+	mux.HandleFunc("/username/sensors/new", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Sensors.GetNew(ctx)
+	if err != nil {
+		t.Errorf("Sensor.GetNew returned error: %+v", err)
+	}
+
+	want := map[string]string{
+		"9": "Hue motion sensor 2",
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Sensor.GetNew returned %+v, want %+v", got, want)
+	}
+}
+
+func TestSensorService_Search(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Sensor_Search.json")
+	mux.HandleFunc("/username/sensors", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, err := client.Sensors.Search(ctx)
+	if err != nil {
+		t.Errorf("Sensor.Search returned error: %+v", err)
+	}
+}
+
+func TestSensorService_Create(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Sensor_Create.json")
+	mux.HandleFunc("/username/sensors", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, "Away flag", payload["name"])
+		assert.Equal(t, SensorTypeCLIPGenericFlag, payload["type"])
+		assert.Equal(t, map[string]interface{}{"flag": false}, payload["state"])
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Sensors.Create(ctx, Sensor{
+		Name:             "Away flag",
+		Type:             SensorTypeCLIPGenericFlag,
+		ModelId:          "GenericFlag",
+		ManufacturerName: "go-hue",
+		SWVersion:        "1.0",
+		UniqueId:         "away-flag",
+		State:            SensorState{Flag: Bool(false)},
+	})
+	if err != nil {
+		t.Errorf("Sensor.Create returned error: %+v", err)
+	}
+
+	assert.Equal(t, "10", got)
+}
+
+func TestSensorService_Rename(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Sensor_Rename.json")
+	mux.HandleFunc("/username/sensors/2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, err := client.Sensors.Rename(ctx, "2", "Bedroom sensor")
+	if err != nil {
+		t.Errorf("Sensor.Rename returned error: %+v", err)
+	}
+}
+
+func TestSensorService_UpdateConfig(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Sensor_UpdateConfig.json")
+	mux.HandleFunc("/username/sensors/2/config", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, map[string]interface{}{"on": false, "sensitivity": float64(1)}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Sensors.UpdateConfig(ctx, "2", SensorConfig{On: Bool(false), Sensitivity: Int(1)})
+	if err != nil {
+		t.Errorf("Sensor.UpdateConfig returned error: %+v", err)
+	}
+
+	assert.Len(t, got, 2)
+}
+
+func TestSensorService_SetState(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Sensor_SetState.json")
+	mux.HandleFunc("/username/sensors/7/state", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Sensors.SetState(ctx, "7", SensorState{Flag: Bool(false)})
+	if err != nil {
+		t.Errorf("Sensor.SetState returned error: %+v", err)
+	}
+
+	want := []ApiResponse{
+		{
+			Success: map[string]interface{}{
+				"/sensors/7/state/flag": false,
+			},
+		},
+	}
+
+	if !cmp.Equal(got, want) {
+		t.Errorf("Sensor.SetState returned %+v, want %+v", got, want)
+	}
+}
+
+func TestSensorService_Delete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Sensor_Delete.json")
+	mux.HandleFunc("/username/sensors/7", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, err := client.Sensors.Delete(ctx, "7")
+	if err != nil {
+		t.Errorf("Sensor.Delete returned error: %+v", err)
+	}
+}
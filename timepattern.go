@@ -0,0 +1,228 @@
+package hue
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimePatternType is the kind of time pattern
+type TimePatternType int
+
+const (
+	TimePatternAbsolute       TimePatternType = iota + 1 // 2026-10-18T07:00:00
+	TimePatternRecurring                                 // W127/T07:00:00
+	TimePatternTimer                                     // PT00:10:00
+	TimePatternRecurringTimer                            // R05/PT00:10:00
+)
+
+// Weekdays is the bitmask of days used by recurring times
+type Weekdays int
+
+const (
+	Sunday    Weekdays = 1 << iota // 1
+	Saturday                       // 2
+	Friday                         // 4
+	Thursday                       // 8
+	Wednesday                      // 16
+	Tuesday                        // 32
+	Monday                         // 64
+
+	Workdays = Monday | Tuesday | Wednesday | Thursday | Friday
+	Weekend  = Saturday | Sunday
+	Everyday = Workdays | Weekend
+)
+
+const timePatternDateLayout = "2006-01-02T15:04:05"
+
+var timePatternRegexp = regexp.MustCompile(`^(?:` +
+	`(?P<date>\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2})|` +
+	`W(?P<weekdays>\d{1,3})/T(?P<timeofday>\d{2}:\d{2}:\d{2})|` +
+	`(?P<recurring>R(?P<count>\d*)/)?PT(?P<timer>\d{2}:\d{2}:\d{2})` +
+	`)(?:A(?P<random>\d{2}:\d{2}:\d{2}))?$`)
+
+// TimePattern is the time format used by schedules
+//
+// Absolute times and recurring times are in the local time of the bridge.
+// Patterns returned by the bridge which ParseTimePattern doesn't support, e.g. the interval
+// W127/T08:00:00/T09:00:00, are kept in Raw with Type 0 and sent back unchanged.
+type TimePattern struct {
+	Type TimePatternType
+	Raw  string // Pattern as returned by the bridge if it couldn't be parsed
+
+	Date        time.Time     // Date and time of an absolute pattern, the location is ignored
+	Weekdays    Weekdays      // Days of a recurring pattern
+	Time        time.Duration // Time of the day of a recurring pattern, or duration of a timer
+	Recurrences int           // How many times a recurring timer runs, 0 means forever
+	Random      time.Duration // Random delay added by the bridge, 0 to disable
+}
+
+// AbsoluteTime returns a pattern which triggers once at t
+func AbsoluteTime(t time.Time) TimePattern {
+	return TimePattern{Type: TimePatternAbsolute, Date: t}
+}
+
+// RecurringTime returns a pattern which triggers on given days at the time of the day, e.g. 7*time.Hour
+func RecurringTime(days Weekdays, timeOfDay time.Duration) TimePattern {
+	return TimePattern{Type: TimePatternRecurring, Weekdays: days, Time: timeOfDay}
+}
+
+// Timer returns a pattern which triggers once after d
+func Timer(d time.Duration) TimePattern {
+	return TimePattern{Type: TimePatternTimer, Time: d}
+}
+
+// RecurringTimer returns a pattern which triggers every d, n times. If n is 0 it runs forever.
+func RecurringTimer(n int, d time.Duration) TimePattern {
+	return TimePattern{Type: TimePatternRecurringTimer, Time: d, Recurrences: n}
+}
+
+// Randomized returns a copy of the pattern which is delayed randomly up to d
+func (p TimePattern) Randomized(d time.Duration) TimePattern {
+	p.Random = d
+	return p
+}
+
+// String returns the pattern in the format of the bridge
+func (p TimePattern) String() string {
+	if p.Type == 0 {
+		return p.Raw
+	}
+
+	var s string
+	switch p.Type {
+	case TimePatternAbsolute:
+		s = p.Date.Format(timePatternDateLayout)
+	case TimePatternRecurring:
+		s = fmt.Sprintf("W%03d/T%v", int(p.Weekdays), formatClock(p.Time))
+	case TimePatternTimer:
+		s = "PT" + formatClock(p.Time)
+	case TimePatternRecurringTimer:
+		if p.Recurrences > 0 {
+			s = fmt.Sprintf("R%02d/PT%v", p.Recurrences, formatClock(p.Time))
+		} else {
+			s = "R/PT" + formatClock(p.Time)
+		}
+	default:
+		return ""
+	}
+
+	if p.Random > 0 {
+		s += "A" + formatClock(p.Random)
+	}
+	return s
+}
+
+// ParseTimePattern parses a time pattern in the format of the bridge
+func ParseTimePattern(s string) (TimePattern, error) {
+	match := timePatternRegexp.FindStringSubmatch(s)
+	if match == nil {
+		return TimePattern{}, fmt.Errorf("hue: invalid time pattern %q", s)
+	}
+	groups := make(map[string]string)
+	for i, name := range timePatternRegexp.SubexpNames() {
+		if name != "" {
+			groups[name] = match[i]
+		}
+	}
+
+	var p TimePattern
+	var err error
+	switch {
+	case groups["date"] != "":
+		p.Type = TimePatternAbsolute
+		p.Date, err = time.Parse(timePatternDateLayout, groups["date"])
+	case groups["weekdays"] != "":
+		p.Type = TimePatternRecurring
+		var days int
+		days, err = strconv.Atoi(groups["weekdays"])
+		if err == nil && (days < 1 || days > int(Everyday)) {
+			err = fmt.Errorf("weekdays out of range")
+		}
+		p.Weekdays = Weekdays(days)
+		if err == nil {
+			p.Time, err = parseClock(groups["timeofday"])
+		}
+	case groups["recurring"] != "":
+		p.Type = TimePatternRecurringTimer
+		if groups["count"] != "" {
+			p.Recurrences, err = strconv.Atoi(groups["count"])
+		}
+		if err == nil {
+			p.Time, err = parseClock(groups["timer"])
+		}
+	default:
+		p.Type = TimePatternTimer
+		p.Time, err = parseClock(groups["timer"])
+	}
+	if err == nil && groups["random"] != "" {
+		p.Random, err = parseClock(groups["random"])
+	}
+	if err != nil {
+		return TimePattern{}, fmt.Errorf("hue: invalid time pattern %q: %v", s, err)
+	}
+
+	return p, nil
+}
+
+// IsZero reports whether the pattern is not set
+func (p TimePattern) IsZero() bool {
+	return p.Type == 0 && p.Raw == ""
+}
+
+func (p TimePattern) MarshalJSON() ([]byte, error) {
+	return json.Marshal(p.String())
+}
+
+func (p *TimePattern) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+	if s == "" {
+		*p = TimePattern{}
+		return nil
+	}
+
+	// Patterns which aren't supported yet must not fail reading every schedule of the bridge
+	parsed, err := ParseTimePattern(s)
+	if err != nil {
+		*p = TimePattern{Raw: s}
+		return nil
+	}
+	*p = parsed
+	return nil
+}
+
+// formatClock formats d as hh:mm:ss
+func formatClock(d time.Duration) string {
+	d = d.Round(time.Second)
+	h := d / time.Hour
+	d -= h * time.Hour
+	m := d / time.Minute
+	d -= m * time.Minute
+	return fmt.Sprintf("%02d:%02d:%02d", h, m, d/time.Second)
+}
+
+// parseClock parses hh:mm:ss
+func parseClock(s string) (time.Duration, error) {
+	parts := strings.Split(s, ":")
+	if len(parts) != 3 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	var values [3]int
+	for i, part := range parts {
+		v, err := strconv.Atoi(part)
+		if err != nil {
+			return 0, fmt.Errorf("invalid time %q", s)
+		}
+		values[i] = v
+	}
+	if values[1] > 59 || values[2] > 59 {
+		return 0, fmt.Errorf("invalid time %q", s)
+	}
+	return time.Duration(values[0])*time.Hour + time.Duration(values[1])*time.Minute + time.Duration(values[2])*time.Second, nil
+}
@@ -0,0 +1,72 @@
+package hue
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimePattern(t *testing.T) {
+	tests := []struct {
+		pattern TimePattern
+		want    string
+	}{
+		{AbsoluteTime(time.Date(2026, 10, 18, 7, 0, 0, 0, time.UTC)), "2026-10-18T07:00:00"},
+		{AbsoluteTime(time.Date(2026, 10, 18, 7, 0, 0, 0, time.UTC)).Randomized(30 * time.Minute), "2026-10-18T07:00:00A00:30:00"},
+		{RecurringTime(Everyday, 7*time.Hour), "W127/T07:00:00"},
+		{RecurringTime(Workdays, 6*time.Hour+45*time.Minute), "W124/T06:45:00"},
+		{RecurringTime(Weekend, 9*time.Hour).Randomized(15 * time.Minute), "W003/T09:00:00A00:15:00"},
+		{Timer(10 * time.Minute), "PT00:10:00"},
+		{Timer(90 * time.Second).Randomized(time.Minute), "PT00:01:30A00:01:00"},
+		{RecurringTimer(5, 10*time.Minute), "R05/PT00:10:00"},
+		{RecurringTimer(0, time.Hour), "R/PT01:00:00"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.pattern.String())
+
+		got, err := ParseTimePattern(tt.want)
+		if err != nil {
+			t.Errorf("ParseTimePattern(%q) returned error: %+v", tt.want, err)
+			continue
+		}
+		assert.Equal(t, tt.want, got.String())
+		assert.Equal(t, tt.pattern.Type, got.Type)
+		assert.Equal(t, tt.pattern.Weekdays, got.Weekdays)
+		assert.Equal(t, tt.pattern.Time, got.Time)
+		assert.Equal(t, tt.pattern.Recurrences, got.Recurrences)
+		assert.Equal(t, tt.pattern.Random, got.Random)
+	}
+}
+
+func TestParseTimePattern_Invalid(t *testing.T) {
+	for _, s := range []string{"", "07:00:00", "W128/T07:00:00", "W000/T07:00:00", "PT00:60:00", "R5PT00:10:00", "2026-13-18T07:00:00"} {
+		_, err := ParseTimePattern(s)
+		assert.Error(t, err, s)
+	}
+}
+
+func TestTimePattern_JSON(t *testing.T) {
+	params := ScheduleParams{LocalTime: &TimePattern{Type: TimePatternTimer, Time: 5 * time.Minute}}
+	bytes, _ := json.Marshal(params)
+	assert.Equal(t, `{"localtime":"PT00:05:00"}`, string(bytes))
+
+	var schedule Schedule
+	err := json.Unmarshal([]byte(`{"localtime":"W064/T08:00:00"}`), &schedule)
+	assert.Nil(t, err)
+	assert.Equal(t, RecurringTime(Monday, 8*time.Hour), schedule.LocalTime)
+}
+
+func TestTimePattern_JSON_Unsupported(t *testing.T) {
+	var schedule Schedule
+	err := json.Unmarshal([]byte(`{"localtime":"W127/T08:00:00/T09:00:00"}`), &schedule)
+	assert.Nil(t, err)
+	assert.Equal(t, TimePattern{Raw: "W127/T08:00:00/T09:00:00"}, schedule.LocalTime)
+	assert.False(t, schedule.LocalTime.IsZero())
+	assert.Equal(t, "W127/T08:00:00/T09:00:00", schedule.LocalTime.String())
+
+	bytes, _ := json.Marshal(ScheduleParams{LocalTime: &schedule.LocalTime})
+	assert.Equal(t, `{"localtime":"W127/T08:00:00/T09:00:00"}`, string(bytes))
+}
@@ -0,0 +1,271 @@
+package hue
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// hueRootCA signs the certificates of bridges with recent firmware
+// https://developers.meethue.com/develop/application-design-guidance/using-https/
+const hueRootCA = `-----BEGIN CERTIFICATE-----
+MIICMjCCAdigAwIBAgIUO7FSLbaxikuXAljzVaurLXWmFw4wCgYIKoZIzj0EAwIw
+OTELMAkGA1UEBhMCTkwxFDASBgNVBAoMC1BoaWxpcHMgSHVlMRQwEgYDVQQDDAty
+b290LWJyaWRnZTAiGA8yMDE3MDEwMTAwMDAwMFoYDzIwMzgwMTE5MDMxNDA3WjA5
+MQswCQYDVQQGEwJOTDEUMBIGA1UECgwLUGhpbGlwcyBIdWUxFDASBgNVBAMMC3Jv
+b3QtYnJpZGdlMFkwEwYHKoZIzj0CAQYIKoZIzj0DAQcDQgAEjNw2tx2AplOf9x86
+aTdvEcL1FU65QDxziKvBpW9XXSIcibAeQiKxegpq8Exbr9v6LBnYbna2VcaK0G22
+jOKkTqOBuTCBtjAPBgNVHRMBAf8EBTADAQH/MA4GA1UdDwEB/wQEAwIBhjAdBgNV
+HQ4EFgQUZ2ONTFrDT6o8ItRnKfqWKnHFGmQwdAYDVR0jBG0wa4AUZ2ONTFrDT6o8
+ItRnKfqWKnHFGmShPaQ7MDkxCzAJBgNVBAYTAk5MMRQwEgYDVQQKDAtQaGlsaXBz
+IEh1ZTEUMBIGA1UEAwwLcm9vdC1icmlkZ2WCFDuxUi22sYpLlwJY81Wrqy11phcO
+MAoGCCqGSM49BAMCA0gAMEUCIEBYYEOsa07TH7E5MJnGw557lVkORgit2Rm1h3B2
+sFgDAiEA1Fj/C3AN5psFMjo0//mrQebo0eKd3aWRx+pQY08mk48=
+-----END CERTIFICATE-----`
+
+// ErrCertificateMismatch is returned when the bridge presents another certificate than the pinned one
+var ErrCertificateMismatch = errors.New("hue: certificate of the bridge doesn't match the pinned certificate")
+
+// ErrBridgeIDRequired is returned when TLSOptions are given without the BridgeID of ClientOptions
+var ErrBridgeIDRequired = errors.New("hue: TLS needs the BridgeID of the bridge")
+
+// TLSOptions connects to the local bridge with HTTPS.
+// The certificate must be signed by the Hue root CA and its common name must be the BridgeID of ClientOptions.
+// Create the client with NewClientWithOptions, it returns ErrBridgeIDRequired if BridgeID is missing
+// where NewClient returns nil.
+type TLSOptions struct {
+	// RootCAs replaces the bundled Hue root CA, e.g. for tests
+	RootCAs *x509.CertPool
+	// TrustOnFirstUse pins the certificate of the first connection and only accepts it afterwards,
+	// so a bridge signed by the Hue root CA can't be replaced by another one with the same ID.
+	// The pin is saved in the CredentialStore of ClientOptions, if there is one.
+	TrustOnFirstUse bool
+}
+
+// HueRootCAs returns a pool with the Hue root CA
+func HueRootCAs() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AppendCertsFromPEM([]byte(hueRootCA))
+	return pool
+}
+
+// BridgeTLSConfig returns a TLS config which only accepts the certificate of the bridge with bridgeID.
+// The chain is verified against the Hue root CA, or RootCAs of opts, and the common name must be bridgeID.
+// A nil opts verifies the chain and the bridge ID, store keeps the pin of TrustOnFirstUse and may be nil.
+func BridgeTLSConfig(bridgeID string, opts *TLSOptions, store CredentialStore) (*tls.Config, error) {
+	if bridgeID == "" {
+		return nil, ErrBridgeIDRequired
+	}
+	if opts == nil {
+		opts = &TLSOptions{}
+	}
+
+	return newBridgeVerifier(bridgeID, opts, store).tlsConfig(), nil
+}
+
+// newLocalClient returns a client for the bridge at host, with HTTPS if opts has TLSOptions
+func newLocalClient(host string, opts *ClientOptions) (*Client, error) {
+	if opts == nil || opts.TLS == nil {
+		return newClient(fmt.Sprintf("http://%v/api/", host), opts)
+	}
+	if opts.BridgeID == "" {
+		return nil, ErrBridgeIDRequired
+	}
+
+	verifier := newBridgeVerifier(opts.BridgeID, opts.TLS, opts.CredentialStore)
+	httpClient, err := newTLSHTTPClient(opts.HttpClient, verifier)
+	if err != nil {
+		return nil, err
+	}
+	o := *opts
+	o.HttpClient = httpClient
+
+	return newClient(fmt.Sprintf("https://%v/api/", host), &o)
+}
+
+// newTLSHTTPClient returns a copy of httpClient, which verifies the certificate of the bridge with verifier
+func newTLSHTTPClient(base *http.Client, verifier *bridgeVerifier) (*http.Client, error) {
+	httpClient := &http.Client{}
+	if base != nil {
+		*httpClient = *base
+	}
+
+	rt := http.DefaultTransport
+	if httpClient.Transport != nil {
+		rt = httpClient.Transport
+	}
+	transport, ok := rt.(*http.Transport)
+	if !ok {
+		return nil, errors.New("hue: TLSOptions need an *http.Transport in the http client")
+	}
+	transport = transport.Clone()
+	transport.TLSClientConfig = verifier.tlsConfig()
+	httpClient.Transport = transport
+
+	return httpClient, nil
+}
+
+// probeTLSBridgeID asks the bridge at host for its ID over HTTPS.
+// The certificate must be signed by the Hue root CA and be issued for the ID the bridge reports.
+func probeTLSBridgeID(ctx context.Context, host string, opts *ClientOptions) (string, error) {
+	verifier := newBridgeVerifier("", opts.TLS, nil)
+	verifier.probe = true
+	httpClient, err := newTLSHTTPClient(opts.HttpClient, verifier)
+	if err != nil {
+		return "", err
+	}
+
+	bridge, err := probeBridge(ctx, httpClient, "https", bridgeAt(host))
+	if err != nil {
+		return "", err
+	}
+
+	if cn := verifier.commonName(); !strings.EqualFold(cn, bridge.ID) {
+		return "", fmt.Errorf("hue: certificate is for bridge %q, not %q", cn, bridge.ID)
+	}
+	return bridge.ID, nil
+}
+
+// bridgeVerifier checks the certificate chain, the bridge ID and the pinned certificate
+type bridgeVerifier struct {
+	roots    *x509.CertPool
+	bridgeID string
+	tofu     bool
+	store    CredentialStore
+	probe    bool // Accept any bridge ID and remember it, see probeTLSBridgeID
+
+	mu     sync.Mutex
+	pinned string // Fingerprint of the certificate, if there is no store
+	cn     string // Common name of the last certificate, when probing
+}
+
+func newBridgeVerifier(bridgeID string, opts *TLSOptions, store CredentialStore) *bridgeVerifier {
+	roots := opts.RootCAs
+	if roots == nil {
+		roots = HueRootCAs()
+	}
+	return &bridgeVerifier{roots: roots, bridgeID: bridgeID, tofu: opts.TrustOnFirstUse, store: store}
+}
+
+func (v *bridgeVerifier) tlsConfig() *tls.Config {
+	return &tls.Config{
+		// The bridge is reached by IP address which isn't in its certificate,
+		// so the certificate is verified by verifyPeerCertificate instead.
+		InsecureSkipVerify:    true,
+		VerifyPeerCertificate: v.verifyPeerCertificate,
+		MinVersion:            tls.VersionTLS12,
+	}
+}
+
+func (v *bridgeVerifier) verifyPeerCertificate(rawCerts [][]byte, _ [][]*x509.Certificate) error {
+	if len(rawCerts) == 0 {
+		return errors.New("hue: bridge didn't present a certificate")
+	}
+	certs := make([]*x509.Certificate, 0, len(rawCerts))
+	for _, raw := range rawCerts {
+		cert, err := x509.ParseCertificate(raw)
+		if err != nil {
+			return err
+		}
+		certs = append(certs, cert)
+	}
+	leaf := certs[0]
+
+	if err := v.verifyChain(certs); err != nil {
+		return err
+	}
+
+	if v.probe {
+		v.mu.Lock()
+		v.cn = leaf.Subject.CommonName
+		v.mu.Unlock()
+		return nil
+	}
+
+	// Bridges use their ID as common name, without it any bridge signed by the Hue root CA is accepted
+	if v.bridgeID == "" {
+		return ErrBridgeIDRequired
+	}
+	if !strings.EqualFold(leaf.Subject.CommonName, v.bridgeID) {
+		return fmt.Errorf("hue: certificate is for bridge %q, not %q", leaf.Subject.CommonName, v.bridgeID)
+	}
+
+	if !v.tofu {
+		return nil
+	}
+
+	sum := sha256.Sum256(leaf.Raw)
+	fingerprint := hex.EncodeToString(sum[:])
+
+	pinned, err := v.loadPin()
+	if err != nil {
+		return err
+	}
+	if pinned != "" {
+		if pinned != fingerprint {
+			return ErrCertificateMismatch
+		}
+		return nil
+	}
+
+	return v.savePin(fingerprint)
+}
+
+func (v *bridgeVerifier) commonName() string {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+	return v.cn
+}
+
+func (v *bridgeVerifier) verifyChain(certs []*x509.Certificate) error {
+	intermediates := x509.NewCertPool()
+	for _, cert := range certs[1:] {
+		intermediates.AddCert(cert)
+	}
+
+	_, err := certs[0].Verify(x509.VerifyOptions{
+		Roots:         v.roots,
+		Intermediates: intermediates,
+		KeyUsages:     []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	})
+	return err
+}
+
+func (v *bridgeVerifier) loadPin() (string, error) {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	if v.store == nil {
+		return v.pinned, nil
+	}
+
+	creds, err := v.store.Load(v.bridgeID)
+	if errors.Is(err, ErrCredentialsNotFound) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return creds.CertificateFingerprint, nil
+}
+
+func (v *bridgeVerifier) savePin(fingerprint string) error {
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	v.pinned = fingerprint
+	if v.store == nil {
+		return nil
+	}
+
+	return updateCredentials(v.store, v.bridgeID, func(creds *Credentials) {
+		creds.CertificateFingerprint = fingerprint
+	})
+}
@@ -0,0 +1,274 @@
+package hue
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestCertificate returns a certificate with the common name cn, signed by parent or self-signed if parent is nil
+func newTestCertificate(t *testing.T, cn string, isCA bool, parent *tls.Certificate) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"Philips Hue"}, CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  isCA,
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.Leaf, parent.PrivateKey.(*ecdsa.PrivateKey)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, _ := x509.ParseCertificate(der)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+// setupTLS returns the host of a bridge serving the lights with cert over HTTPS
+func setupTLS(t *testing.T, cert *tls.Certificate) (string, func()) {
+	bytes, _ := ioutil.ReadFile("testdata/Light_GetAll.json")
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/username/lights" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	var server *httptest.Server
+	if cert == nil {
+		server = httptest.NewTLSServer(handler)
+	} else {
+		server = httptest.NewUnstartedServer(handler)
+		server.TLS = &tls.Config{Certificates: []tls.Certificate{*cert}}
+		server.StartTLS()
+	}
+
+	u, _ := url.Parse(server.URL)
+	return u.Host, server.Close
+}
+
+func TestTLS_HueRootCA(t *testing.T) {
+	block, _ := pem.Decode([]byte(hueRootCA))
+	ca, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("Hue root CA can't be parsed: %v", err)
+	}
+
+	assert.Equal(t, "root-bridge", ca.Subject.CommonName)
+	assert.NoError(t, ca.CheckSignatureFrom(ca))
+}
+
+func TestTLS_BridgeCertificate(t *testing.T) {
+	ca := newTestCertificate(t, "root-bridge", true, nil)
+	bridge := newTestCertificate(t, testBridgeId, false, &ca)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Leaf)
+
+	host, teardown := setupTLS(t, &bridge)
+	defer teardown()
+
+	ctx := context.Background()
+	client := NewClient(host, "username", &ClientOptions{BridgeID: testBridgeId, TLS: &TLSOptions{RootCAs: roots}})
+	assert.Equal(t, "https", client.baseURL.Scheme)
+	lights, _, err := client.Lights.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("Lights.GetAll returned error: %+v", err)
+	}
+	assert.NotEmpty(t, lights)
+
+	// The certificate is for another bridge
+	client = NewClient(host, "username", &ClientOptions{BridgeID: "ECB5FAFFFE0A1B2C", TLS: &TLSOptions{RootCAs: roots}})
+	_, _, err = client.Lights.GetAll(ctx)
+	assert.Error(t, err)
+
+	// The certificate isn't signed by the Hue root CA
+	client = NewClient(host, "username", &ClientOptions{BridgeID: testBridgeId, TLS: &TLSOptions{}})
+	_, _, err = client.Lights.GetAll(ctx)
+	assert.Error(t, err)
+}
+
+func TestTLS_TrustOnFirstUse(t *testing.T) {
+	ca := newTestCertificate(t, "root-bridge", true, nil)
+	first := newTestCertificate(t, testBridgeId, false, &ca)
+	other := newTestCertificate(t, testBridgeId, false, &ca)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Leaf)
+
+	host, teardown := setupTLS(t, &first)
+	defer teardown()
+	otherHost, otherTeardown := setupTLS(t, &other)
+	defer otherTeardown()
+
+	store := NewMemoryCredentialStore()
+	opts := &ClientOptions{BridgeID: testBridgeId, CredentialStore: store, TLS: &TLSOptions{RootCAs: roots, TrustOnFirstUse: true}}
+
+	ctx := context.Background()
+	_, _, err := NewClient(host, "username", opts).Lights.GetAll(ctx)
+	if err != nil {
+		t.Fatalf("Lights.GetAll returned error: %+v", err)
+	}
+
+	creds, err := store.Load(testBridgeId)
+	assert.NoError(t, err)
+	assert.Len(t, creds.CertificateFingerprint, 64)
+
+	// The pin is used by new clients
+	_, _, err = NewClient(host, "username", opts).Lights.GetAll(ctx)
+	assert.NoError(t, err)
+
+	// Another bridge signed by the Hue root CA with the same ID
+	_, _, err = NewClient(otherHost, "username", opts).Lights.GetAll(ctx)
+	assert.True(t, errors.Is(err, ErrCertificateMismatch))
+}
+
+func TestTLS_TrustOnFirstUse_VerifiesChain(t *testing.T) {
+	selfSigned := newTestCertificate(t, testBridgeId, false, nil)
+	host, teardown := setupTLS(t, &selfSigned)
+	defer teardown()
+
+	store := NewMemoryCredentialStore()
+	client := NewClient(host, "username", &ClientOptions{BridgeID: testBridgeId, CredentialStore: store, TLS: &TLSOptions{TrustOnFirstUse: true}})
+
+	_, _, err := client.Lights.GetAll(context.Background())
+	assert.Error(t, err)
+
+	// Nothing is pinned
+	_, err = store.Load(testBridgeId)
+	assert.True(t, errors.Is(err, ErrCredentialsNotFound))
+}
+
+func TestTLS_BridgeIDRequired(t *testing.T) {
+	_, err := newLocalClient("127.0.0.1", &ClientOptions{TLS: &TLSOptions{TrustOnFirstUse: true}})
+	assert.True(t, errors.Is(err, ErrBridgeIDRequired))
+
+	assert.Nil(t, NewClient("127.0.0.1", "username", &ClientOptions{TLS: &TLSOptions{}}))
+
+	client, err := NewClientWithOptions("127.0.0.1", "username", &ClientOptions{TLS: &TLSOptions{}})
+	assert.Nil(t, client)
+	assert.True(t, errors.Is(err, ErrBridgeIDRequired))
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestNewClientWithOptions(t *testing.T) {
+	client, err := NewClientWithOptions("127.0.0.1", "username", &ClientOptions{BridgeID: testBridgeId, TLS: &TLSOptions{}})
+	if assert.NoError(t, err) {
+		assert.Equal(t, "username", client.GetClientID())
+		assert.Equal(t, "https", client.baseURL.Scheme)
+	}
+
+	// TLS can only be configured on an *http.Transport
+	custom := &http.Client{Transport: roundTripperFunc(http.DefaultTransport.RoundTrip)}
+	client, err = NewClientWithOptions("127.0.0.1", "username", &ClientOptions{BridgeID: testBridgeId, TLS: &TLSOptions{}, HttpClient: custom})
+	assert.Nil(t, client)
+	assert.Error(t, err)
+}
+
+// setupTLSPairing returns the host of a bridge creating users over HTTPS with cert
+func setupTLSPairing(t *testing.T, cert *tls.Certificate) (string, func()) {
+	mux := http.NewServeMux()
+	config, _ := ioutil.ReadFile("testdata/Bridge_PublicConfig.json")
+	mux.HandleFunc("/api/0/config", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		fmt.Fprint(w, string(config))
+	})
+	apiHandler := http.NewServeMux()
+	apiHandler.Handle("/api/0/config", mux)
+	apiHandler.Handle("/api/", http.StripPrefix("/api", mux))
+	handleCreateUser(t, mux, 1)
+
+	server := httptest.NewUnstartedServer(apiHandler)
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{*cert}}
+	server.StartTLS()
+
+	u, _ := url.Parse(server.URL)
+	return u.Host, server.Close
+}
+
+func TestTLS_Pair(t *testing.T) {
+	ca := newTestCertificate(t, "root-bridge", true, nil)
+	bridge := newTestCertificate(t, strings.ToLower(testBridgeId), false, &ca)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Leaf)
+
+	host, teardown := setupTLSPairing(t, &bridge)
+	defer teardown()
+
+	store := NewMemoryCredentialStore()
+	opts := &ClientOptions{CredentialStore: store, TLS: &TLSOptions{RootCAs: roots, TrustOnFirstUse: true}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	creds, err := Pair(ctx, host, "go-hue#test", &PairOptions{ClientOptions: opts})
+	if err != nil {
+		t.Fatalf("Pair returned error: %+v", err)
+	}
+	assert.Equal(t, "83b7780291a6ceffbe0bd049104df", creds.Username)
+
+	// The user and the pin are saved for the probed bridge ID
+	stored, err := store.Load(testBridgeId)
+	assert.NoError(t, err)
+	assert.Equal(t, creds.Username, stored.Username)
+	assert.Len(t, stored.CertificateFingerprint, 64)
+}
+
+func TestTLS_Pair_OtherBridge(t *testing.T) {
+	ca := newTestCertificate(t, "root-bridge", true, nil)
+	// Signed by the Hue root CA, but the bridge reports another ID
+	bridge := newTestCertificate(t, "ECB5FAFFFE0A1B2C", false, &ca)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Leaf)
+
+	host, teardown := setupTLSPairing(t, &bridge)
+	defer teardown()
+
+	opts := &ClientOptions{TLS: &TLSOptions{RootCAs: roots}}
+	_, err := Pair(context.Background(), host, "go-hue#test", &PairOptions{ClientOptions: opts})
+	assert.Error(t, err)
+}
+
+func TestBridgeTLSConfig(t *testing.T) {
+	_, err := BridgeTLSConfig("", nil, nil)
+	assert.True(t, errors.Is(err, ErrBridgeIDRequired))
+
+	config, err := BridgeTLSConfig(testBridgeId, nil, nil)
+	assert.NoError(t, err)
+	assert.NotNil(t, config.VerifyPeerCertificate)
+
+	// Any bridge signed by the Hue root CA isn't enough
+	other := newTestCertificate(t, testBridgeId, false, nil)
+	assert.Error(t, config.VerifyPeerCertificate(other.Certificate, nil))
+}
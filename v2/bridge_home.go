@@ -0,0 +1,40 @@
+package v2
+
+import (
+	"context"
+
+	hue "github.com/firstthumb/go-hue"
+)
+
+// BridgeHomeService has functions for bridge homes, the group of every room and device
+type BridgeHomeService service
+
+func (s *BridgeHomeService) bridgeHomeServicePath(params ...string) string {
+	return s.client.path(ResourceTypeBridgeHome, params...)
+}
+
+// GetAll returns all bridge homes, the group of every room and device
+func (s *BridgeHomeService) GetAll(ctx context.Context) ([]BridgeHome, *Response, error) {
+	var homes []BridgeHome
+	resp, err := s.client.getResources(ctx, s.bridgeHomeServicePath(), &homes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return homes, resp, nil
+}
+
+// Get returns bridge home by id
+func (s *BridgeHomeService) Get(ctx context.Context, id string) (*BridgeHome, *Response, error) {
+	var homes []BridgeHome
+	resp, err := s.client.getResources(ctx, s.bridgeHomeServicePath(id), &homes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(homes) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &homes[0], resp, nil
+}
@@ -0,0 +1,10 @@
+package v2
+
+// BridgeHome struct that represents the group of every room and device of the bridge
+type BridgeHome struct {
+	ID       string               `json:"id"`
+	IDV1     string               `json:"id_v1,omitempty"` // Always /groups/0
+	Type     string               `json:"type"`
+	Children []ResourceIdentifier `json:"children"` // Rooms and devices which aren't in a room
+	Services []ResourceIdentifier `json:"services"` // The grouped_light of every light
+}
@@ -0,0 +1,296 @@
+// Package v2 is a client for the CLIP API v2 of the Philips Hue bridge.
+//
+// The v2 API is served over HTTPS at https://<bridge>/clip/v2/resource and
+// authenticates with the hue-application-key header, the key is the
+// username created with the v1 API. The certificate of the bridge must be
+// signed by the Hue root CA and be issued for the bridge ID.
+//
+//	client := v2.NewClient(host, "<YOUR USER TOKEN>", &v2.ClientOptions{BridgeID: bridge.ID})
+//	lights, resp, err := client.Lights.GetAll(context.Background())
+package v2
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strings"
+
+	hue "github.com/firstthumb/go-hue"
+)
+
+const (
+	defaultBasePath      = "clip/v2/"
+	userAgent            = "go-hue"
+	applicationKeyHeader = "hue-application-key"
+)
+
+type Response struct {
+	*http.Response
+}
+
+type Client struct {
+	client  *http.Client
+	baseURL *url.URL
+
+	userAgent      string
+	applicationKey string // username for hue bridge
+	common         service
+
+	eventStreamRetry *hue.RetryPolicy
+
+	Lights               *LightService
+	GroupedLights        *GroupedLightService
+	Rooms                *RoomService
+	Zones                *ZoneService
+	Devices              *DeviceService
+	Scenes               *SceneService
+	BridgeHomes          *BridgeHomeService
+	Motions              *MotionService
+	Temperatures         *TemperatureService
+	LightLevels          *LightLevelService
+	Buttons              *ButtonService
+	DevicePowers         *DevicePowerService
+	ZigbeeConnectivities *ZigbeeConnectivityService
+	Events               *EventService
+
+	EntertainmentConfigurations *EntertainmentConfigurationService
+}
+
+type service struct {
+	client *Client
+}
+
+type ClientOptions struct {
+	// HttpClient is used as it is, its transport must verify the certificate e.g. with hue.BridgeTLSConfig
+	HttpClient *http.Client
+
+	// BridgeID is the common name of the bridge certificate, it is required unless HttpClient is set
+	BridgeID string
+	// TLS replaces the Hue root CA or pins the certificate, nil verifies the chain and BridgeID
+	TLS *hue.TLSOptions
+	// CredentialStore keeps the pinned certificate of TLS.TrustOnFirstUse
+	CredentialStore hue.CredentialStore
+
+	// EventStreamRetry is the backoff between reconnects of Events.Subscribe, MaxRetries is ignored.
+	// nil waits 1s after the stream dropped, doubling with every failed reconnect up to 30s.
+	EventStreamRetry *hue.RetryPolicy
+
+	// InsecureSkipVerify accepts any certificate, so the application key is sent to whoever answers.
+	// It must be set explicitly and is only meant for testing.
+	InsecureSkipVerify bool
+}
+
+// newHTTPClient returns the http client of opts or one verifying the certificate of the bridge
+func newHTTPClient(opts *ClientOptions) (*http.Client, error) {
+	if opts == nil {
+		opts = &ClientOptions{}
+	}
+	if opts.HttpClient != nil {
+		return opts.HttpClient, nil
+	}
+
+	var tlsConfig *tls.Config
+	if opts.InsecureSkipVerify {
+		tlsConfig = &tls.Config{InsecureSkipVerify: true}
+	} else {
+		var err error
+		tlsConfig, err = hue.BridgeTLSConfig(opts.BridgeID, opts.TLS, opts.CredentialStore)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.TLSClientConfig = tlsConfig
+
+	return &http.Client{Transport: transport}, nil
+}
+
+func newClient(host string, opts *ClientOptions) (*Client, error) {
+	httpClient, err := newHTTPClient(opts)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := url.Parse(host)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{client: httpClient, baseURL: u, userAgent: userAgent}
+	if opts != nil {
+		c.eventStreamRetry = opts.EventStreamRetry
+	}
+	c.common.client = c
+
+	c.Lights = (*LightService)(&c.common)
+	c.GroupedLights = (*GroupedLightService)(&c.common)
+	c.Rooms = (*RoomService)(&c.common)
+	c.Zones = (*ZoneService)(&c.common)
+	c.Devices = (*DeviceService)(&c.common)
+	c.Scenes = (*SceneService)(&c.common)
+	c.BridgeHomes = (*BridgeHomeService)(&c.common)
+	c.Motions = (*MotionService)(&c.common)
+	c.Temperatures = (*TemperatureService)(&c.common)
+	c.LightLevels = (*LightLevelService)(&c.common)
+	c.Buttons = (*ButtonService)(&c.common)
+	c.DevicePowers = (*DevicePowerService)(&c.common)
+	c.ZigbeeConnectivities = (*ZigbeeConnectivityService)(&c.common)
+	c.Events = (*EventService)(&c.common)
+	c.EntertainmentConfigurations = (*EntertainmentConfigurationService)(&c.common)
+
+	return c, nil
+}
+
+// NewClient returns v2 client of the bridge, applicationKey is the username of the v1 API.
+// It returns nil if opts has neither BridgeID, HttpClient nor InsecureSkipVerify.
+func NewClient(host, applicationKey string, opts *ClientOptions) *Client {
+	c, err := newClient(fmt.Sprintf("https://%v/%v", host, defaultBasePath), opts)
+	if err != nil {
+		return nil
+	}
+	c.applicationKey = applicationKey
+
+	return c
+}
+
+// GetHost returns ip address of hue bridge
+func (c *Client) GetHost() string {
+	return c.baseURL.Host
+}
+
+func (c *Client) newRequest(method, url string, payload interface{}) (*http.Request, error) {
+	if !strings.HasSuffix(c.baseURL.Path, "/") {
+		return nil, fmt.Errorf("BaseURL must have a trailing slash, but %q does not", c.baseURL)
+	}
+	u, err := c.baseURL.Parse(url)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf io.ReadWriter
+	if payload != nil {
+		buf = &bytes.Buffer{}
+		enc := json.NewEncoder(buf)
+		enc.SetEscapeHTML(false)
+		err := enc.Encode(payload)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	req, err := http.NewRequest(method, u.String(), buf)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set(applicationKeyHeader, c.applicationKey)
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+	return req, nil
+}
+
+// do sends the request and decodes data of the envelope into v
+func (c *Client) do(ctx context.Context, req *http.Request, v interface{}) (*Response, error) {
+	if ctx == nil {
+		return nil, errors.New("context must be non-nil")
+	}
+	req = req.WithContext(ctx)
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return &Response{Response: resp}, err
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return &Response{Response: resp}, err
+	}
+
+	var env envelope
+	if len(bytes.TrimSpace(body)) > 0 {
+		err = json.Unmarshal(body, &env)
+	}
+
+	// Errors of the bridge take precedence, e.g. a 404 may not have a JSON body
+	apiErr := apiErrors(req, resp.StatusCode, env.Errors)
+
+	if err == nil && v != nil && len(env.Data) > 0 {
+		err = json.Unmarshal(env.Data, v)
+	}
+	if apiErr != nil {
+		err = apiErr
+	}
+
+	return &Response{Response: resp}, err
+}
+
+func (c *Client) path(resourceType string, params ...string) string {
+	return strings.Join(append([]string{"resource", resourceType}, params...), "/")
+}
+
+// getResources decodes the resources at path into v, which must be a pointer to a slice
+func (c *Client) getResources(ctx context.Context, path string, v interface{}) (*Response, error) {
+	req, err := c.newRequest(http.MethodGet, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(ctx, req, v)
+}
+
+// createResource returns identifier of the created resource
+func (c *Client) createResource(ctx context.Context, path string, payload interface{}) (*ResourceIdentifier, *Response, error) {
+	req, err := c.newRequest(http.MethodPost, path, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []ResourceIdentifier
+	resp, err := c.do(ctx, req, &ids)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(ids) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &ids[0], resp, nil
+}
+
+// updateResource returns identifiers of the updated resources.
+// They are returned together with the error since the bridge may apply a request partially.
+func (c *Client) updateResource(ctx context.Context, path string, payload interface{}) ([]ResourceIdentifier, *Response, error) {
+	req, err := c.newRequest(http.MethodPut, path, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var ids []ResourceIdentifier
+	resp, err := c.do(ctx, req, &ids)
+
+	return ids, resp, err
+}
+
+func (c *Client) deleteResource(ctx context.Context, path string) (*Response, error) {
+	req, err := c.newRequest(http.MethodDelete, path, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.do(ctx, req, nil)
+}
@@ -0,0 +1,212 @@
+package v2
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	hue "github.com/firstthumb/go-hue"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	baseURLPath = "/clip/v2"
+
+	testApplicationKey = "username"
+	testBridgeId       = "001788FFFE23BFC2"
+	testLightId        = "3a6710fa-4474-4eba-b533-5e6e72968feb"
+)
+
+func setup() (client *Client, mux *http.ServeMux, serverURL string, teardown func()) {
+	mux = http.NewServeMux()
+
+	apiHandler := http.NewServeMux()
+	apiHandler.Handle(baseURLPath+"/", http.StripPrefix(baseURLPath, mux))
+	apiHandler.Handle("/eventstream/", mux)
+	apiHandler.HandleFunc("/", func(w http.ResponseWriter, req *http.Request) {
+
+		http.Error(w, "Client.BaseURL path prefix is not preserved in the request URL.", http.StatusInternalServerError)
+	})
+
+	server := httptest.NewTLSServer(apiHandler)
+
+	url, _ := url.Parse(server.URL)
+	client = NewClient(url.Host, testApplicationKey, &ClientOptions{HttpClient: server.Client()})
+
+	return client, mux, server.URL, server.Close
+}
+
+func testMethod(t *testing.T, r *http.Request, want string) {
+	t.Helper()
+	if got := r.Method; got != want {
+		t.Errorf("Request method: %v, want %v", got, want)
+	}
+}
+
+func getPayload(t *testing.T, r *http.Request, payload interface{}) {
+	bytes, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		t.Errorf("Request payload failed to read")
+	}
+	err = json.Unmarshal(bytes, payload)
+	if err != nil {
+		t.Errorf("Request payload failed to unmarshal")
+	}
+}
+
+func TestClient_ApplicationKey(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_GetAll.json")
+	mux.HandleFunc("/resource/light", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, testApplicationKey, r.Header.Get(applicationKeyHeader))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, _, err := client.Lights.GetAll(ctx)
+	assert.NoError(t, err)
+}
+
+func TestClient_Unauthorized(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Unauthorized.json")
+	mux.HandleFunc("/resource/light", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, _, err := client.Lights.GetAll(ctx)
+
+	assert.True(t, errors.Is(err, hue.ErrUnauthorizedUser))
+
+	var e *hue.Error
+	assert.True(t, errors.As(err, &e))
+	assert.Equal(t, "unauthorized user", e.Description)
+	assert.Equal(t, "/clip/v2/resource/light", e.Address)
+}
+
+func TestClient_NotFound(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/resource/light/unknown", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	ctx := context.Background()
+	_, resp, err := client.Lights.Get(ctx, "unknown")
+
+	assert.True(t, errors.Is(err, hue.ErrResourceNotAvailable))
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestClient_MultipleErrors(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_UpdateError.json")
+	mux.HandleFunc(fmt.Sprintf("/resource/light/%s", testLightId), func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, _, err := client.Lights.Update(ctx, testLightId, LightUpdate{Dimming: &Dimming{Brightness: 200}})
+
+	var errs hue.MultiError
+	assert.True(t, errors.As(err, &errs))
+	assert.Len(t, errs, 2)
+	assert.True(t, errors.Is(err, hue.ErrInvalidValue))
+}
+
+// newTestCertificate returns a certificate with the common name cn, signed by parent or self-signed if parent is nil
+func newTestCertificate(t *testing.T, cn string, parent *tls.Certificate) tls.Certificate {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	serial, _ := rand.Int(rand.Reader, big.NewInt(1<<62))
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{Organization: []string{"Philips Hue"}, CommonName: cn},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  parent == nil,
+	}
+
+	signer, signerKey := template, key
+	if parent != nil {
+		signer, signerKey = parent.Leaf, parent.PrivateKey.(*ecdsa.PrivateKey)
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, signer, &key.PublicKey, signerKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaf, _ := x509.ParseCertificate(der)
+
+	return tls.Certificate{Certificate: [][]byte{der}, PrivateKey: key, Leaf: leaf}
+}
+
+func TestClient_VerifiesCertificate(t *testing.T) {
+	ca := newTestCertificate(t, "root-bridge", nil)
+	bridge := newTestCertificate(t, testBridgeId, &ca)
+	roots := x509.NewCertPool()
+	roots.AddCert(ca.Leaf)
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_GetAll.json")
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, testApplicationKey, r.Header.Get(applicationKeyHeader))
+		fmt.Fprint(w, string(bytes))
+	}))
+	server.TLS = &tls.Config{Certificates: []tls.Certificate{bridge}}
+	server.StartTLS()
+	defer server.Close()
+	u, _ := url.Parse(server.URL)
+
+	ctx := context.Background()
+	client := NewClient(u.Host, testApplicationKey, &ClientOptions{BridgeID: testBridgeId, TLS: &hue.TLSOptions{RootCAs: roots}})
+	lights, _, err := client.Lights.GetAll(ctx)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, lights)
+
+	// The certificate is for another bridge
+	client = NewClient(u.Host, testApplicationKey, &ClientOptions{BridgeID: "ECB5FAFFFE0A1B2C", TLS: &hue.TLSOptions{RootCAs: roots}})
+	_, _, err = client.Lights.GetAll(ctx)
+	assert.Error(t, err)
+
+	// The certificate isn't signed by the Hue root CA
+	client = NewClient(u.Host, testApplicationKey, &ClientOptions{BridgeID: testBridgeId})
+	_, _, err = client.Lights.GetAll(ctx)
+	assert.Error(t, err)
+
+	// The bridge can't be verified without its ID
+	assert.Nil(t, NewClient(u.Host, testApplicationKey, nil))
+
+	client = NewClient(u.Host, testApplicationKey, &ClientOptions{InsecureSkipVerify: true})
+	_, _, err = client.Lights.GetAll(ctx)
+	assert.NoError(t, err)
+}
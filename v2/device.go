@@ -0,0 +1,45 @@
+package v2
+
+import (
+	"context"
+
+	hue "github.com/firstthumb/go-hue"
+)
+
+// DeviceService has functions for devices
+type DeviceService service
+
+func (s *DeviceService) deviceServicePath(params ...string) string {
+	return s.client.path(ResourceTypeDevice, params...)
+}
+
+// GetAll returns all devices
+func (s *DeviceService) GetAll(ctx context.Context) ([]Device, *Response, error) {
+	var devices []Device
+	resp, err := s.client.getResources(ctx, s.deviceServicePath(), &devices)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return devices, resp, nil
+}
+
+// Get returns device by id
+func (s *DeviceService) Get(ctx context.Context, id string) (*Device, *Response, error) {
+	var devices []Device
+	resp, err := s.client.getResources(ctx, s.deviceServicePath(id), &devices)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(devices) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &devices[0], resp, nil
+}
+
+// Update changes metadata of the device or makes it identify itself
+func (s *DeviceService) Update(ctx context.Context, id string, payload DeviceUpdate) ([]ResourceIdentifier, *Response, error) {
+	return s.client.updateResource(ctx, s.deviceServicePath(id), payload)
+}
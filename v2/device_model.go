@@ -0,0 +1,31 @@
+package v2
+
+// Device struct that represents a physical device, e.g. a bulb or a switch
+type Device struct {
+	ID          string               `json:"id"`
+	IDV1        string               `json:"id_v1,omitempty"`
+	Type        string               `json:"type"`
+	ProductData ProductData          `json:"product_data"`
+	Metadata    Metadata             `json:"metadata"`
+	Services    []ResourceIdentifier `json:"services"` // Services of the device, e.g. light, button or zigbee_connectivity
+}
+
+type ProductData struct {
+	ModelID          string `json:"model_id"`
+	ManufacturerName string `json:"manufacturer_name"`
+	ProductName      string `json:"product_name"`
+	ProductArchetype string `json:"product_archetype"`
+	Certified        bool   `json:"certified"`
+	SoftwareVersion  string `json:"software_version"`
+}
+
+// DeviceUpdate is used to change the device, nil fields are not changed.
+type DeviceUpdate struct {
+	Metadata *Metadata `json:"metadata,omitempty"`
+	Identify *Identify `json:"identify,omitempty"`
+}
+
+// Identify makes the device blink
+type Identify struct {
+	Action string `json:"action"` // Always identify
+}
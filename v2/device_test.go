@@ -0,0 +1,81 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testDeviceId = "f4e6a2a0-7a6d-4b1a-9c3c-7e2f6f3f0c11"
+
+func TestDeviceService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Device_Get.json")
+	mux.HandleFunc(fmt.Sprintf("/resource/device/%s", testDeviceId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Devices.Get(ctx, testDeviceId)
+	if err != nil {
+		t.Errorf("Device.Get returned error: %+v", err)
+	}
+
+	assert.Equal(t, "LCA001", got.ProductData.ModelID)
+	assert.True(t, got.ProductData.Certified)
+	assert.Equal(t, ResourceIdentifier{RID: testLightId, RType: ResourceTypeLight}, got.Services[0])
+}
+
+func TestDeviceService_Update(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Device_Update.json")
+	mux.HandleFunc(fmt.Sprintf("/resource/device/%s", testDeviceId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, map[string]interface{}{"identify": map[string]interface{}{"action": "identify"}}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, _, err := client.Devices.Update(ctx, testDeviceId, DeviceUpdate{Identify: &Identify{Action: "identify"}})
+	if err != nil {
+		t.Errorf("Device.Update returned error: %+v", err)
+	}
+}
+
+func TestBridgeHomeService_GetAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/BridgeHome_GetAll.json")
+	mux.HandleFunc("/resource/bridge_home", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.BridgeHomes.GetAll(ctx)
+	if err != nil {
+		t.Errorf("BridgeHome.GetAll returned error: %+v", err)
+	}
+
+	assert.Len(t, got, 1)
+	assert.Equal(t, "/groups/0", got[0].IDV1)
+	assert.Equal(t, ResourceIdentifier{RID: testRoomId, RType: ResourceTypeRoom}, got[0].Children[0])
+}
@@ -0,0 +1,45 @@
+package v2
+
+import (
+	"context"
+
+	hue "github.com/firstthumb/go-hue"
+)
+
+// EntertainmentConfigurationService has functions for entertainment configurations
+type EntertainmentConfigurationService service
+
+func (s *EntertainmentConfigurationService) entertainmentConfigurationServicePath(params ...string) string {
+	return s.client.path(ResourceTypeEntertainmentConfiguration, params...)
+}
+
+// GetAll returns all entertainment configurations
+func (s *EntertainmentConfigurationService) GetAll(ctx context.Context) ([]EntertainmentConfiguration, *Response, error) {
+	var configurations []EntertainmentConfiguration
+	resp, err := s.client.getResources(ctx, s.entertainmentConfigurationServicePath(), &configurations)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return configurations, resp, nil
+}
+
+// Get returns entertainment configuration by id
+func (s *EntertainmentConfigurationService) Get(ctx context.Context, id string) (*EntertainmentConfiguration, *Response, error) {
+	var configurations []EntertainmentConfiguration
+	resp, err := s.client.getResources(ctx, s.entertainmentConfigurationServicePath(id), &configurations)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(configurations) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &configurations[0], resp, nil
+}
+
+// Update starts or stops streaming, or changes metadata of the configuration
+func (s *EntertainmentConfigurationService) Update(ctx context.Context, id string, payload EntertainmentConfigurationUpdate) ([]ResourceIdentifier, *Response, error) {
+	return s.client.updateResource(ctx, s.entertainmentConfigurationServicePath(id), payload)
+}
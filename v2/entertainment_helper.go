@@ -0,0 +1,15 @@
+package v2
+
+import "context"
+
+// Start claims the entertainment configuration for streaming
+func (s *EntertainmentConfigurationService) Start(ctx context.Context, id string) error {
+	_, _, err := s.Update(ctx, id, EntertainmentConfigurationUpdate{Action: EntertainmentActionStart})
+	return err
+}
+
+// Stop ends streaming to the entertainment configuration
+func (s *EntertainmentConfigurationService) Stop(ctx context.Context, id string) error {
+	_, _, err := s.Update(ctx, id, EntertainmentConfigurationUpdate{Action: EntertainmentActionStop})
+	return err
+}
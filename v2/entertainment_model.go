@@ -0,0 +1,50 @@
+package v2
+
+// Entertainment configuration statuses
+const (
+	EntertainmentStatusActive   = "active"
+	EntertainmentStatusInactive = "inactive"
+)
+
+// Entertainment configuration actions
+const (
+	EntertainmentActionStart = "start"
+	EntertainmentActionStop  = "stop"
+)
+
+// EntertainmentConfiguration struct that represents an entertainment area, its channels are streamed with HueStream v2
+type EntertainmentConfiguration struct {
+	ID                string                 `json:"id"`
+	IDV1              string                 `json:"id_v1,omitempty"` // Address of the Entertainment group in the v1 API, e.g. /groups/5
+	Type              string                 `json:"type"`
+	Metadata          Metadata               `json:"metadata"`
+	ConfigurationType string                 `json:"configuration_type"` // screen, monitor, music, 3dspace or other
+	Status            string                 `json:"status"`             // One of EntertainmentStatus*
+	ActiveStreamer    *ResourceIdentifier    `json:"active_streamer,omitempty"`
+	Channels          []EntertainmentChannel `json:"channels"`
+}
+
+// EntertainmentChannel is a channel of the stream, it may drive several lights
+type EntertainmentChannel struct {
+	ChannelID int             `json:"channel_id"`
+	Position  Position        `json:"position"`
+	Members   []ChannelMember `json:"members"`
+}
+
+// Position in the entertainment area, each coordinate is between -1 and 1
+type Position struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+type ChannelMember struct {
+	Service ResourceIdentifier `json:"service"`
+	Index   int                `json:"index"`
+}
+
+// EntertainmentConfigurationUpdate is used to start or stop streaming, nil fields are not changed.
+type EntertainmentConfigurationUpdate struct {
+	Action   string    `json:"action,omitempty"` // One of EntertainmentAction*
+	Metadata *Metadata `json:"metadata,omitempty"`
+}
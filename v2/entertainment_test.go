@@ -0,0 +1,54 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testEntertainmentConfigurationId = "1a8d99cc-967b-44f2-9202-43f976c0fa6b"
+
+func TestEntertainmentConfigurationService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleFixture(t, mux, "/resource/entertainment_configuration/"+testEntertainmentConfigurationId, "testdata/EntertainmentConfiguration_Get.json")
+
+	ctx := context.Background()
+	got, _, err := client.EntertainmentConfigurations.Get(ctx, testEntertainmentConfigurationId)
+	if err != nil {
+		t.Errorf("EntertainmentConfigurations.Get returned error: %+v", err)
+	}
+
+	assert.Equal(t, "TV area", got.Metadata.Name)
+	assert.Equal(t, EntertainmentStatusInactive, got.Status)
+	assert.Equal(t, Position{X: -0.5, Y: 0.8}, got.Channels[0].Position)
+}
+
+func TestEntertainmentConfigurationService_Start(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/EntertainmentConfiguration_Update.json")
+	mux.HandleFunc("/resource/entertainment_configuration/"+testEntertainmentConfigurationId, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, map[string]interface{}{"action": "start"}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	err := client.EntertainmentConfigurations.Start(ctx, testEntertainmentConfigurationId)
+	if err != nil {
+		t.Errorf("EntertainmentConfigurations.Start returned error: %+v", err)
+	}
+}
@@ -0,0 +1,61 @@
+package v2
+
+import (
+	"encoding/json"
+	"net/http"
+
+	hue "github.com/firstthumb/go-hue"
+)
+
+// envelope is the body of every v2 response
+type envelope struct {
+	Errors []apiError      `json:"errors"`
+	Data   json.RawMessage `json:"data"`
+}
+
+type apiError struct {
+	Description string `json:"description"`
+}
+
+// errorType maps status code of a v2 response onto the error types of the v1 API,
+// so both clients can be checked with errors.Is against the same errors, e.g. hue.ErrUnauthorizedUser.
+func errorType(statusCode int) int {
+	switch statusCode {
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return hue.ErrorTypeUnauthorizedUser
+	case http.StatusNotFound:
+		return hue.ErrorTypeResourceNotAvailable
+	case http.StatusMethodNotAllowed:
+		return hue.ErrorTypeMethodNotAvailable
+	case http.StatusTooManyRequests, http.StatusInsufficientStorage:
+		return hue.ErrorTypeTooManyItems
+	}
+	if statusCode >= http.StatusInternalServerError {
+		return hue.ErrorTypeInternalError
+	}
+	return hue.ErrorTypeInvalidValue
+}
+
+// apiErrors returns the errors of the envelope as *hue.Error, several as hue.MultiError.
+// A failure status without errors in the body is reported with the status text.
+func apiErrors(req *http.Request, statusCode int, errs []apiError) error {
+	if len(errs) == 0 && statusCode < http.StatusBadRequest {
+		return nil
+	}
+
+	address := req.URL.Path
+	t := errorType(statusCode)
+
+	if len(errs) == 0 {
+		return &hue.Error{Type: t, Address: address, Description: http.StatusText(statusCode)}
+	}
+
+	var result hue.MultiError
+	for _, e := range errs {
+		result = append(result, &hue.Error{Type: t, Address: address, Description: e.Description})
+	}
+	if len(result) == 1 {
+		return result[0]
+	}
+	return result
+}
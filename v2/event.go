@@ -0,0 +1,183 @@
+package v2
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	hue "github.com/firstthumb/go-hue"
+)
+
+const eventStreamPath = "/eventstream/clip/v2"
+
+// Default backoff between reconnects of the event stream, see ClientOptions.EventStreamRetry
+var (
+	eventStreamRetry      = time.Second
+	eventStreamMaxBackoff = 30 * time.Second
+)
+
+// streamState is kept between the connections of a subscription
+type streamState struct {
+	lastEventID string
+	retry       time.Duration // First wait after the stream dropped, the bridge may change it with the retry field
+	maxBackoff  time.Duration
+	failures    int  // Reconnects in a row which didn't get a stream
+	connected   bool // Whether the bridge accepted the last connection
+}
+
+// backoff returns the wait before the next reconnect. It doubles with every failed reconnect up to
+// maxBackoff and is between half and the full backoff, so clients don't reconnect at the same time.
+func (st *streamState) backoff() time.Duration {
+	if st.connected {
+		st.failures = 0
+	}
+	st.failures++
+
+	max := st.maxBackoff
+	if max < st.retry {
+		max = st.retry
+	}
+	backoff := st.retry << uint(st.failures-1)
+	if backoff > max || backoff <= 0 {
+		backoff = max
+	}
+	return backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+}
+
+// maxEventSize is the largest line the stream may contain, a message can carry many resources
+const maxEventSize = 1 << 20
+
+// EventService subscribes to the event stream of the bridge
+type EventService service
+
+// Subscribe calls handler for every event until ctx is done.
+// The stream is reconnected when it drops, events which were missed are replayed using Last-Event-ID.
+// Failed reconnects back off exponentially, see ClientOptions.EventStreamRetry.
+// It returns ctx.Err() when ctx is done, or the error of the bridge when the application key is not authorized.
+func (s *EventService) Subscribe(ctx context.Context, handler func(Event)) error {
+	state := &streamState{retry: eventStreamRetry, maxBackoff: eventStreamMaxBackoff}
+	if p := s.client.eventStreamRetry; p != nil {
+		if p.MinBackoff > 0 {
+			state.retry = p.MinBackoff
+		}
+		if p.MaxBackoff > 0 {
+			state.maxBackoff = p.MaxBackoff
+		}
+	}
+
+	for {
+		state.connected = false
+		err := s.stream(ctx, state, handler)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if errors.Is(err, hue.ErrUnauthorizedUser) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(state.backoff()):
+		}
+	}
+}
+
+// Channel delivers the events over a channel, it is closed when ctx is done or the stream fails permanently.
+// The reason is sent to the error channel, which is closed as well.
+func (s *EventService) Channel(ctx context.Context) (<-chan Event, <-chan error) {
+	events := make(chan Event)
+	errs := make(chan error, 1)
+
+	go func() {
+		defer close(errs)
+		defer close(events)
+
+		errs <- s.Subscribe(ctx, func(e Event) {
+			select {
+			case events <- e:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return events, errs
+}
+
+// stream reads the stream until it drops, state is updated with every message
+func (s *EventService) stream(ctx context.Context, state *streamState, handler func(Event)) error {
+	req, err := s.client.newRequest(http.MethodGet, eventStreamPath, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	if state.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", state.lastEventID)
+	}
+
+	resp, err := s.client.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return apiErrors(req, resp.StatusCode, nil)
+	}
+	state.connected = true
+
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxEventSize)
+
+	var id string
+	var data []string
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// A blank line dispatches the message
+			if id != "" {
+				state.lastEventID = id
+			}
+			if len(data) > 0 {
+				var events []Event
+				if err := json.Unmarshal([]byte(strings.Join(data, "\n")), &events); err != nil {
+					return err
+				}
+				for _, e := range events {
+					handler(e)
+				}
+			}
+			id, data = "", nil
+			continue
+		}
+		if strings.HasPrefix(line, ":") {
+			continue // Comment, the bridge sends ": hi" after connecting
+		}
+
+		field, value := line, ""
+		if i := strings.Index(line, ":"); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+		switch field {
+		case "id":
+			id = value
+		case "data":
+			data = append(data, value)
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil {
+				state.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	return errors.New("hue: event stream closed")
+}
@@ -0,0 +1,65 @@
+package v2
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// Event types
+const (
+	EventTypeAdd    = "add"
+	EventTypeUpdate = "update"
+	EventTypeDelete = "delete"
+	EventTypeError  = "error"
+)
+
+// Event struct that represents a change of resources on the bridge
+type Event struct {
+	ID           string      `json:"id"`
+	Type         string      `json:"type"` // One of EventType*
+	CreationTime time.Time   `json:"creationtime"`
+	Data         []EventData `json:"data"` // Changed resources
+}
+
+// EventData is a resource in an event. Updates only contain the changed attributes,
+// use Decode to read them into the model of the resource.
+type EventData struct {
+	ID    string              `json:"id"`
+	IDV1  string              `json:"id_v1,omitempty"`
+	Type  string              `json:"type"` // One of ResourceType*
+	Owner *ResourceIdentifier `json:"owner,omitempty"`
+
+	raw json.RawMessage
+}
+
+// UnmarshalJSON keeps the payload, so it can be decoded into the model of the resource
+func (d *EventData) UnmarshalJSON(data []byte) error {
+	type eventData EventData
+	var v eventData
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	*d = EventData(v)
+	d.raw = append(json.RawMessage(nil), data...)
+	return nil
+}
+
+// MarshalJSON returns the payload of the resource
+func (d EventData) MarshalJSON() ([]byte, error) {
+	if d.raw != nil {
+		return d.raw, nil
+	}
+	type eventData EventData
+	return json.Marshal(eventData(d))
+}
+
+// Decode reads the payload into v, e.g. *Light for an event of a light.
+// Attributes which aren't in the event keep their zero values.
+func (d EventData) Decode(v interface{}) error {
+	return json.Unmarshal(d.raw, v)
+}
+
+// Resource returns identifier of the resource
+func (d EventData) Resource() ResourceIdentifier {
+	return ResourceIdentifier{RID: d.ID, RType: d.Type}
+}
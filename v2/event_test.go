@@ -0,0 +1,217 @@
+package v2
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	hue "github.com/firstthumb/go-hue"
+	"github.com/stretchr/testify/assert"
+)
+
+// sseMessage returns a message of the event stream with the compacted fixture as data
+func sseMessage(t *testing.T, id, file string) string {
+	raw, _ := ioutil.ReadFile(file)
+	buf := &bytes.Buffer{}
+	if err := json.Compact(buf, raw); err != nil {
+		t.Fatalf("Fixture %v is not valid: %v", file, err)
+	}
+	return fmt.Sprintf("id: %s\ndata: %s\n\n", id, buf.String())
+}
+
+func TestEventService_Subscribe(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	defer func(d time.Duration) { eventStreamRetry = d }(eventStreamRetry)
+	eventStreamRetry = time.Millisecond
+
+	var mu sync.Mutex
+	var lastEventIDs []string
+	mux.HandleFunc("/eventstream/clip/v2", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		assert.Equal(t, testApplicationKey, r.Header.Get(applicationKeyHeader))
+
+		mu.Lock()
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		connection := len(lastEventIDs)
+		mu.Unlock()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, ": hi\n\n")
+		if connection == 1 {
+			// The connection drops after the first message
+			fmt.Fprint(w, sseMessage(t, "1634569495:0", "testdata/Event_Update.json"))
+			return
+		}
+		fmt.Fprint(w, sseMessage(t, "1634569502:0", "testdata/Event_Button.json"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got []Event
+	err := client.Events.Subscribe(ctx, func(e Event) {
+		got = append(got, e)
+		if len(got) == 2 {
+			cancel()
+		}
+	})
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []string{"", "1634569495:0"}, lastEventIDs)
+
+	assert.Len(t, got, 2)
+	assert.Equal(t, EventTypeUpdate, got[0].Type)
+	assert.Equal(t, time.Date(2021, 10, 18, 15, 4, 55, 0, time.UTC), got[0].CreationTime)
+	assert.Len(t, got[0].Data, 2)
+	assert.Equal(t, ResourceIdentifier{RID: testLightId, RType: ResourceTypeLight}, got[0].Data[0].Resource())
+	assert.Equal(t, "/lights/1", got[0].Data[0].IDV1)
+
+	var light Light
+	assert.NoError(t, got[0].Data[0].Decode(&light))
+	assert.False(t, light.On.On)
+	assert.Nil(t, light.Dimming)
+
+	var group GroupedLight
+	assert.NoError(t, got[0].Data[1].Decode(&group))
+	assert.Equal(t, 40.5, group.Dimming.Brightness)
+
+	var button Button
+	assert.NoError(t, got[1].Data[0].Decode(&button))
+	assert.Equal(t, ButtonEventInitialPress, button.Button.LastEvent)
+}
+
+func TestEventService_Channel(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/eventstream/clip/v2", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, sseMessage(t, "1634569502:0", "testdata/Event_Button.json"))
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	events, errs := client.Events.Channel(ctx)
+
+	e := <-events
+	assert.Equal(t, ResourceTypeButton, e.Data[0].Type)
+
+	cancel()
+	for range events {
+	}
+	assert.Equal(t, context.Canceled, <-errs)
+}
+
+func TestEventService_Unauthorized(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/eventstream/clip/v2", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := client.Events.Subscribe(ctx, func(e Event) {})
+	assert.True(t, errors.Is(err, hue.ErrUnauthorizedUser))
+}
+
+func TestEventData_MarshalJSON(t *testing.T) {
+	raw := `{"id":"1","type":"light","on":{"on":true}}`
+
+	var d EventData
+	assert.NoError(t, json.Unmarshal([]byte(raw), &d))
+
+	got, err := json.Marshal(d)
+	assert.NoError(t, err)
+	assert.JSONEq(t, raw, string(got))
+}
+
+func TestStreamState_Backoff(t *testing.T) {
+	state := &streamState{retry: 100 * time.Millisecond, maxBackoff: 400 * time.Millisecond}
+
+	between := func(min, max time.Duration) {
+		t.Helper()
+		got := state.backoff()
+		assert.True(t, got >= min && got <= max, "backoff %v is not between %v and %v", got, min, max)
+	}
+
+	// Failed reconnects double the backoff up to maxBackoff
+	between(50*time.Millisecond, 100*time.Millisecond)
+	between(100*time.Millisecond, 200*time.Millisecond)
+	between(200*time.Millisecond, 400*time.Millisecond)
+	between(200*time.Millisecond, 400*time.Millisecond)
+
+	// A stream which was accepted starts over
+	state.connected = true
+	between(50*time.Millisecond, 100*time.Millisecond)
+	state.connected = false
+	between(100*time.Millisecond, 200*time.Millisecond)
+
+	// The bridge may ask for a longer wait than maxBackoff
+	state.retry = time.Second
+	between(500*time.Millisecond, time.Second)
+}
+
+func TestEventService_Subscribe_Backoff(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	client.eventStreamRetry = &hue.RetryPolicy{MinBackoff: 20 * time.Millisecond, MaxBackoff: 80 * time.Millisecond}
+
+	var mu sync.Mutex
+	var connects []time.Time
+	mux.HandleFunc("/eventstream/clip/v2", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		connects = append(connects, time.Now())
+		connection := len(connects)
+		mu.Unlock()
+
+		// The bridge is busy for the first three connections
+		if connection <= 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "text/event-stream")
+		fmt.Fprint(w, sseMessage(t, "1634569502:0", "testdata/Event_Button.json"))
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var got int
+	err := client.Events.Subscribe(ctx, func(e Event) {
+		got++
+		if got == 2 {
+			cancel()
+		}
+	})
+	assert.Equal(t, context.Canceled, err)
+
+	mu.Lock()
+	defer mu.Unlock()
+	if !assert.Len(t, connects, 5) {
+		return
+	}
+
+	// Waits of 20ms, 40ms and 80ms with jitter of up to half, then 20ms again after the stream was accepted
+	for i, min := range []time.Duration{10, 20, 40, 10} {
+		wait := connects[i+1].Sub(connects[i])
+		assert.True(t, wait >= min*time.Millisecond, "reconnect %d after %v, want at least %vms", i+1, wait, int(min))
+	}
+	assert.True(t, connects[4].Sub(connects[3]) < 40*time.Millisecond, "backoff wasn't reset after a successful connect")
+}
@@ -0,0 +1,45 @@
+package v2
+
+import (
+	"context"
+
+	hue "github.com/firstthumb/go-hue"
+)
+
+// GroupedLightService has functions for grouped lights, the lights of a room or zone
+type GroupedLightService service
+
+func (s *GroupedLightService) groupedLightServicePath(params ...string) string {
+	return s.client.path(ResourceTypeGroupedLight, params...)
+}
+
+// GetAll returns all grouped lights, the lights of a room or zone
+func (s *GroupedLightService) GetAll(ctx context.Context) ([]GroupedLight, *Response, error) {
+	var groupedLights []GroupedLight
+	resp, err := s.client.getResources(ctx, s.groupedLightServicePath(), &groupedLights)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return groupedLights, resp, nil
+}
+
+// Get returns grouped light by id
+func (s *GroupedLightService) Get(ctx context.Context, id string) (*GroupedLight, *Response, error) {
+	var groupedLights []GroupedLight
+	resp, err := s.client.getResources(ctx, s.groupedLightServicePath(id), &groupedLights)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(groupedLights) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &groupedLights[0], resp, nil
+}
+
+// Update changes the state of every light in the group
+func (s *GroupedLightService) Update(ctx context.Context, id string, payload GroupedLightUpdate) ([]ResourceIdentifier, *Response, error) {
+	return s.client.updateResource(ctx, s.groupedLightServicePath(id), payload)
+}
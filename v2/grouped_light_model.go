@@ -0,0 +1,22 @@
+package v2
+
+// GroupedLight struct that represents the lights of a room, zone or bridge home
+type GroupedLight struct {
+	ID      string             `json:"id"`
+	IDV1    string             `json:"id_v1,omitempty"` // Address of the group in the v1 API, e.g. /groups/1
+	Type    string             `json:"type"`
+	Owner   ResourceIdentifier `json:"owner"` // Room, zone or bridge home of the lights
+	On      *On                `json:"on,omitempty"`
+	Dimming *Dimming           `json:"dimming,omitempty"`
+	Alert   *Alert             `json:"alert,omitempty"`
+}
+
+// GroupedLightUpdate is used to change every light of the group, nil fields are not changed.
+type GroupedLightUpdate struct {
+	On               *On                     `json:"on,omitempty"`
+	Dimming          *Dimming                `json:"dimming,omitempty"`
+	ColorTemperature *ColorTemperatureUpdate `json:"color_temperature,omitempty"`
+	Color            *Color                  `json:"color,omitempty"`
+	Dynamics         *DynamicsUpdate         `json:"dynamics,omitempty"`
+	Alert            *AlertUpdate            `json:"alert,omitempty"`
+}
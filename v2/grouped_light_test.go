@@ -0,0 +1,67 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testGroupedLightId = "e1d4b1f0-2a5c-4b3e-9f6d-8c7b6a5d4e3f"
+
+func TestGroupedLightService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/GroupedLight_Get.json")
+	mux.HandleFunc(fmt.Sprintf("/resource/grouped_light/%s", testGroupedLightId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.GroupedLights.Get(ctx, testGroupedLightId)
+	if err != nil {
+		t.Errorf("GroupedLight.Get returned error: %+v", err)
+	}
+
+	assert.Equal(t, ResourceIdentifier{RID: testRoomId, RType: ResourceTypeRoom}, got.Owner)
+	assert.True(t, got.On.On)
+	assert.Equal(t, 82.35, got.Dimming.Brightness)
+}
+
+func TestGroupedLightService_Update(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/GroupedLight_Update.json")
+	mux.HandleFunc(fmt.Sprintf("/resource/grouped_light/%s", testGroupedLightId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, map[string]interface{}{
+			"on":                map[string]interface{}{"on": true},
+			"color_temperature": map[string]interface{}{"mirek": float64(300)},
+		}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.GroupedLights.Update(ctx, testGroupedLightId, GroupedLightUpdate{
+		On:               &On{On: true},
+		ColorTemperature: &ColorTemperatureUpdate{Mirek: 300},
+	})
+	if err != nil {
+		t.Errorf("GroupedLight.Update returned error: %+v", err)
+	}
+
+	assert.Len(t, got, 1)
+}
@@ -0,0 +1,45 @@
+package v2
+
+import (
+	"context"
+
+	hue "github.com/firstthumb/go-hue"
+)
+
+// LightService has functions for lights
+type LightService service
+
+func (s *LightService) lightServicePath(params ...string) string {
+	return s.client.path(ResourceTypeLight, params...)
+}
+
+// GetAll returns all lights
+func (s *LightService) GetAll(ctx context.Context) ([]Light, *Response, error) {
+	var lights []Light
+	resp, err := s.client.getResources(ctx, s.lightServicePath(), &lights)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return lights, resp, nil
+}
+
+// Get returns light by id
+func (s *LightService) Get(ctx context.Context, id string) (*Light, *Response, error) {
+	var lights []Light
+	resp, err := s.client.getResources(ctx, s.lightServicePath(id), &lights)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(lights) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &lights[0], resp, nil
+}
+
+// Update changes the state or metadata of the light
+func (s *LightService) Update(ctx context.Context, id string, payload LightUpdate) ([]ResourceIdentifier, *Response, error) {
+	return s.client.updateResource(ctx, s.lightServicePath(id), payload)
+}
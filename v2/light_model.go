@@ -0,0 +1,30 @@
+package v2
+
+// Light struct that represents a light of the v2 API
+type Light struct {
+	ID               string             `json:"id"`
+	IDV1             string             `json:"id_v1,omitempty"` // Address of the light in the v1 API, e.g. /lights/1
+	Type             string             `json:"type"`
+	Owner            ResourceIdentifier `json:"owner"` // Device of the light
+	Metadata         Metadata           `json:"metadata"`
+	On               On                 `json:"on"`
+	Dimming          *Dimming           `json:"dimming,omitempty"`
+	ColorTemperature *ColorTemperature  `json:"color_temperature,omitempty"`
+	Color            *Color             `json:"color,omitempty"`
+	Dynamics         *Dynamics          `json:"dynamics,omitempty"`
+	Alert            *Alert             `json:"alert,omitempty"`
+	Effects          *Effects           `json:"effects,omitempty"`
+	Mode             string             `json:"mode"` // normal or streaming
+}
+
+// LightUpdate is used to change the light, nil fields are not changed.
+type LightUpdate struct {
+	Metadata         *Metadata               `json:"metadata,omitempty"`
+	On               *On                     `json:"on,omitempty"`
+	Dimming          *Dimming                `json:"dimming,omitempty"`
+	ColorTemperature *ColorTemperatureUpdate `json:"color_temperature,omitempty"`
+	Color            *Color                  `json:"color,omitempty"`
+	Dynamics         *DynamicsUpdate         `json:"dynamics,omitempty"`
+	Alert            *AlertUpdate            `json:"alert,omitempty"`
+	Effects          *Effects                `json:"effects,omitempty"`
+}
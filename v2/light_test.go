@@ -0,0 +1,105 @@
+package v2
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLightService_GetAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_GetAll.json")
+	mux.HandleFunc("/resource/light", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Lights.GetAll(ctx)
+	if err != nil {
+		t.Errorf("Light.GetAll returned error: %+v", err)
+	}
+
+	var result struct {
+		Data []Light `json:"data"`
+	}
+	json.Unmarshal(bytes, &result)
+
+	if !reflect.DeepEqual(got, result.Data) {
+		t.Errorf("Light.GetAll returned %+v, want %+v", got, result.Data)
+	}
+
+	assert.Len(t, got, 2)
+	assert.Equal(t, "/lights/1", got[0].IDV1)
+	assert.Nil(t, got[0].ColorTemperature.Mirek)
+	assert.Equal(t, "C", got[0].Color.GamutType)
+	assert.Nil(t, got[1].Color)
+}
+
+func TestLightService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_Get.json")
+	mux.HandleFunc(fmt.Sprintf("/resource/light/%s", testLightId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Lights.Get(ctx, testLightId)
+	if err != nil {
+		t.Errorf("Light.Get returned error: %+v", err)
+	}
+
+	assert.Equal(t, testLightId, got.ID)
+	assert.Equal(t, "Hue color lamp 1", got.Metadata.Name)
+	assert.True(t, got.On.On)
+	assert.Equal(t, 366, *got.ColorTemperature.Mirek)
+}
+
+func TestLightService_Update(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Light_Update.json")
+	mux.HandleFunc(fmt.Sprintf("/resource/light/%s", testLightId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, map[string]interface{}{
+			"on":       map[string]interface{}{"on": true},
+			"dimming":  map[string]interface{}{"brightness": float64(50)},
+			"color":    map[string]interface{}{"xy": map[string]interface{}{"x": 0.3, "y": 0.3}},
+			"dynamics": map[string]interface{}{"duration": float64(400)},
+		}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Lights.Update(ctx, testLightId, LightUpdate{
+		On:       &On{On: true},
+		Dimming:  &Dimming{Brightness: 50},
+		Color:    &Color{XY: XY{X: 0.3, Y: 0.3}},
+		Dynamics: &DynamicsUpdate{Duration: 400},
+	})
+	if err != nil {
+		t.Errorf("Light.Update returned error: %+v", err)
+	}
+
+	assert.Equal(t, []ResourceIdentifier{{RID: testLightId, RType: ResourceTypeLight}}, got)
+}
@@ -0,0 +1,104 @@
+package v2
+
+// Resource types of the v2 API
+const (
+	ResourceTypeLight              = "light"
+	ResourceTypeGroupedLight       = "grouped_light"
+	ResourceTypeRoom               = "room"
+	ResourceTypeZone               = "zone"
+	ResourceTypeDevice             = "device"
+	ResourceTypeScene              = "scene"
+	ResourceTypeBridgeHome         = "bridge_home"
+	ResourceTypeMotion             = "motion"
+	ResourceTypeTemperature        = "temperature"
+	ResourceTypeLightLevel         = "light_level"
+	ResourceTypeButton             = "button"
+	ResourceTypeDevicePower        = "device_power"
+	ResourceTypeZigbeeConnectivity = "zigbee_connectivity"
+
+	ResourceTypeEntertainmentConfiguration = "entertainment_configuration"
+)
+
+// ResourceIdentifier points at a resource, e.g. {"rid": "...", "rtype": "light"}
+type ResourceIdentifier struct {
+	RID   string `json:"rid"`   // Id of the resource
+	RType string `json:"rtype"` // Type of the resource, one of ResourceType*
+}
+
+// Metadata is the configuration of a resource which can be changed by the user
+type Metadata struct {
+	Name      string `json:"name,omitempty"`
+	Archetype string `json:"archetype,omitempty"` // Icon of the resource, e.g. sultan_bulb or living_room
+}
+
+type On struct {
+	On bool `json:"on"`
+}
+
+type Dimming struct {
+	Brightness  float64 `json:"brightness"`              // Brightness percentage, 0 is not off
+	MinDimLevel float64 `json:"min_dim_level,omitempty"` // Lowest brightness the light supports, read only
+}
+
+// XY is a color in CIE color space
+type XY struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+}
+
+type Gamut struct {
+	Red   XY `json:"red"`
+	Green XY `json:"green"`
+	Blue  XY `json:"blue"`
+}
+
+type Color struct {
+	XY        XY     `json:"xy"`
+	Gamut     *Gamut `json:"gamut,omitempty"`      // Read only
+	GamutType string `json:"gamut_type,omitempty"` // A, B, C or other, read only
+}
+
+type MirekSchema struct {
+	MirekMinimum int `json:"mirek_minimum"`
+	MirekMaximum int `json:"mirek_maximum"`
+}
+
+type ColorTemperature struct {
+	Mirek       *int        `json:"mirek"`       // Color temperature in mirek, nil when the light is in xy mode
+	MirekValid  bool        `json:"mirek_valid"` // Whether Mirek is in the schema
+	MirekSchema MirekSchema `json:"mirek_schema"`
+}
+
+// ColorTemperatureUpdate changes the color temperature
+type ColorTemperatureUpdate struct {
+	Mirek int `json:"mirek"`
+}
+
+type Dynamics struct {
+	Status       string   `json:"status"` // dynamic_palette or none
+	StatusValues []string `json:"status_values"`
+	Speed        float64  `json:"speed"`
+	SpeedValid   bool     `json:"speed_valid"`
+}
+
+// DynamicsUpdate is the transition of an update
+type DynamicsUpdate struct {
+	Duration int      `json:"duration,omitempty"` // Transition time in milliseconds
+	Speed    *float64 `json:"speed,omitempty"`
+}
+
+type Alert struct {
+	ActionValues []string `json:"action_values"`
+}
+
+// AlertUpdate runs an alert effect, e.g. breathe
+type AlertUpdate struct {
+	Action string `json:"action"`
+}
+
+type Effects struct {
+	Effect       string   `json:"effect,omitempty"`
+	EffectValues []string `json:"effect_values,omitempty"`
+	Status       string   `json:"status,omitempty"`
+	StatusValues []string `json:"status_values,omitempty"`
+}
@@ -0,0 +1,103 @@
+package v2
+
+import (
+	"context"
+
+	hue "github.com/firstthumb/go-hue"
+)
+
+// RoomService has functions for rooms
+type RoomService service
+
+func (s *RoomService) roomServicePath(params ...string) string {
+	return s.client.path(ResourceTypeRoom, params...)
+}
+
+// GetAll returns all rooms
+func (s *RoomService) GetAll(ctx context.Context) ([]Room, *Response, error) {
+	var rooms []Room
+	resp, err := s.client.getResources(ctx, s.roomServicePath(), &rooms)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return rooms, resp, nil
+}
+
+// Get returns room by id
+func (s *RoomService) Get(ctx context.Context, id string) (*Room, *Response, error) {
+	var rooms []Room
+	resp, err := s.client.getResources(ctx, s.roomServicePath(id), &rooms)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(rooms) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &rooms[0], resp, nil
+}
+
+// Create creates the room, returns identifier of the created room
+func (s *RoomService) Create(ctx context.Context, payload GroupParams) (*ResourceIdentifier, *Response, error) {
+	return s.client.createResource(ctx, s.roomServicePath(), payload)
+}
+
+// Update changes children or metadata of the room
+func (s *RoomService) Update(ctx context.Context, id string, payload GroupParams) ([]ResourceIdentifier, *Response, error) {
+	return s.client.updateResource(ctx, s.roomServicePath(id), payload)
+}
+
+// Delete removes the room
+func (s *RoomService) Delete(ctx context.Context, id string) (*Response, error) {
+	return s.client.deleteResource(ctx, s.roomServicePath(id))
+}
+
+// ZoneService has functions for zones
+type ZoneService service
+
+func (s *ZoneService) zoneServicePath(params ...string) string {
+	return s.client.path(ResourceTypeZone, params...)
+}
+
+// GetAll returns all zones
+func (s *ZoneService) GetAll(ctx context.Context) ([]Zone, *Response, error) {
+	var zones []Zone
+	resp, err := s.client.getResources(ctx, s.zoneServicePath(), &zones)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return zones, resp, nil
+}
+
+// Get returns zone by id
+func (s *ZoneService) Get(ctx context.Context, id string) (*Zone, *Response, error) {
+	var zones []Zone
+	resp, err := s.client.getResources(ctx, s.zoneServicePath(id), &zones)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(zones) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &zones[0], resp, nil
+}
+
+// Create creates the zone, returns identifier of the created zone
+func (s *ZoneService) Create(ctx context.Context, payload GroupParams) (*ResourceIdentifier, *Response, error) {
+	return s.client.createResource(ctx, s.zoneServicePath(), payload)
+}
+
+// Update changes children or metadata of the zone
+func (s *ZoneService) Update(ctx context.Context, id string, payload GroupParams) ([]ResourceIdentifier, *Response, error) {
+	return s.client.updateResource(ctx, s.zoneServicePath(id), payload)
+}
+
+// Delete removes the zone
+func (s *ZoneService) Delete(ctx context.Context, id string) (*Response, error) {
+	return s.client.deleteResource(ctx, s.zoneServicePath(id))
+}
@@ -0,0 +1,28 @@
+package v2
+
+// Room struct that represents a room, a device can be in one room only
+type Room struct {
+	ID       string               `json:"id"`
+	IDV1     string               `json:"id_v1,omitempty"` // Address of the group in the v1 API, e.g. /groups/1
+	Type     string               `json:"type"`
+	Metadata Metadata             `json:"metadata"`
+	Children []ResourceIdentifier `json:"children"` // Devices in the room
+	Services []ResourceIdentifier `json:"services"` // Services of the room, e.g. its grouped_light
+}
+
+// Zone struct that represents a zone, lights of any room can be grouped in a zone
+type Zone struct {
+	ID       string               `json:"id"`
+	IDV1     string               `json:"id_v1,omitempty"`
+	Type     string               `json:"type"`
+	Metadata Metadata             `json:"metadata"`
+	Children []ResourceIdentifier `json:"children"` // Lights in the zone
+	Services []ResourceIdentifier `json:"services"`
+}
+
+// GroupParams is used to create or update a room or zone, nil fields are not changed.
+// Children replace the existing ones as a whole.
+type GroupParams struct {
+	Metadata *Metadata            `json:"metadata,omitempty"`
+	Children []ResourceIdentifier `json:"children,omitempty"`
+}
@@ -0,0 +1,107 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testRoomId = "5c2a8b1e-3d4f-4a6b-8c9d-0e1f2a3b4c5d"
+
+func TestRoomService_GetAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Room_GetAll.json")
+	mux.HandleFunc("/resource/room", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Rooms.GetAll(ctx)
+	if err != nil {
+		t.Errorf("Room.GetAll returned error: %+v", err)
+	}
+
+	assert.Len(t, got, 1)
+	assert.Equal(t, testRoomId, got[0].ID)
+	assert.Equal(t, Metadata{Name: "Living room", Archetype: "living_room"}, got[0].Metadata)
+	assert.Len(t, got[0].Children, 2)
+	assert.Equal(t, ResourceIdentifier{RID: testGroupedLightId, RType: ResourceTypeGroupedLight}, got[0].Services[0])
+}
+
+func TestRoomService_Create(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Room_Create.json")
+	mux.HandleFunc("/resource/room", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		var payload GroupParams
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, "Kitchen", payload.Metadata.Name)
+		assert.Equal(t, []ResourceIdentifier{{RID: "f4e6a2a0-7a6d-4b1a-9c3c-7e2f6f3f0c11", RType: ResourceTypeDevice}}, payload.Children)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Rooms.Create(ctx, GroupParams{
+		Metadata: &Metadata{Name: "Kitchen", Archetype: "kitchen"},
+		Children: []ResourceIdentifier{{RID: "f4e6a2a0-7a6d-4b1a-9c3c-7e2f6f3f0c11", RType: ResourceTypeDevice}},
+	})
+	if err != nil {
+		t.Errorf("Room.Create returned error: %+v", err)
+	}
+
+	assert.Equal(t, &ResourceIdentifier{RID: "9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d", RType: ResourceTypeRoom}, got)
+}
+
+func TestRoomService_Delete(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Room_Delete.json")
+	mux.HandleFunc("/resource/room/9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	_, err := client.Rooms.Delete(ctx, "9a8b7c6d-5e4f-4a3b-2c1d-0e9f8a7b6c5d")
+	if err != nil {
+		t.Errorf("Room.Delete returned error: %+v", err)
+	}
+}
+
+func TestZoneService_GetAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Zone_GetAll.json")
+	mux.HandleFunc("/resource/zone", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Zones.GetAll(ctx)
+	if err != nil {
+		t.Errorf("Zone.GetAll returned error: %+v", err)
+	}
+
+	assert.Len(t, got, 1)
+	assert.Equal(t, "Reading corner", got[0].Metadata.Name)
+	assert.Equal(t, ResourceIdentifier{RID: testLightId, RType: ResourceTypeLight}, got[0].Children[0])
+}
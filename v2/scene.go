@@ -0,0 +1,55 @@
+package v2
+
+import (
+	"context"
+
+	hue "github.com/firstthumb/go-hue"
+)
+
+// SceneService has functions for scenes
+type SceneService service
+
+func (s *SceneService) sceneServicePath(params ...string) string {
+	return s.client.path(ResourceTypeScene, params...)
+}
+
+// GetAll returns all scenes
+func (s *SceneService) GetAll(ctx context.Context) ([]Scene, *Response, error) {
+	var scenes []Scene
+	resp, err := s.client.getResources(ctx, s.sceneServicePath(), &scenes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return scenes, resp, nil
+}
+
+// Get returns scene by id
+func (s *SceneService) Get(ctx context.Context, id string) (*Scene, *Response, error) {
+	var scenes []Scene
+	resp, err := s.client.getResources(ctx, s.sceneServicePath(id), &scenes)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(scenes) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &scenes[0], resp, nil
+}
+
+// Create creates the scene, returns identifier of the created scene
+func (s *SceneService) Create(ctx context.Context, payload SceneParams) (*ResourceIdentifier, *Response, error) {
+	return s.client.createResource(ctx, s.sceneServicePath(), payload)
+}
+
+// Update changes actions, metadata or speed of the scene, it can also recall the scene
+func (s *SceneService) Update(ctx context.Context, id string, payload SceneParams) ([]ResourceIdentifier, *Response, error) {
+	return s.client.updateResource(ctx, s.sceneServicePath(id), payload)
+}
+
+// Delete removes the scene
+func (s *SceneService) Delete(ctx context.Context, id string) (*Response, error) {
+	return s.client.deleteResource(ctx, s.sceneServicePath(id))
+}
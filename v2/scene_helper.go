@@ -0,0 +1,9 @@
+package v2
+
+import "context"
+
+// Recall applies the scene to its lights
+func (s *SceneService) Recall(ctx context.Context, id string) error {
+	_, _, err := s.Update(ctx, id, SceneParams{Recall: &SceneRecall{Action: SceneRecallActive}})
+	return err
+}
@@ -0,0 +1,57 @@
+package v2
+
+// Scene recall actions
+const (
+	SceneRecallActive         = "active"
+	SceneRecallStatic         = "static"
+	SceneRecallDynamicPalette = "dynamic_palette"
+)
+
+// Scene struct that represents a scene of a room or zone
+type Scene struct {
+	ID          string             `json:"id"`
+	IDV1        string             `json:"id_v1,omitempty"` // Address of the scene in the v1 API, e.g. /scenes/3T2SvsxvwteNNys
+	Type        string             `json:"type"`
+	Metadata    SceneMetadata      `json:"metadata"`
+	Group       ResourceIdentifier `json:"group"` // Room or zone of the scene
+	Actions     []SceneAction      `json:"actions"`
+	Speed       float64            `json:"speed"`
+	AutoDynamic bool               `json:"auto_dynamic"`
+}
+
+type SceneMetadata struct {
+	Name  string              `json:"name,omitempty"`
+	Image *ResourceIdentifier `json:"image,omitempty"` // Public image of the scene
+}
+
+// SceneAction is the state of a light in the scene
+type SceneAction struct {
+	Target ResourceIdentifier `json:"target"`
+	Action LightAction        `json:"action"`
+}
+
+// LightAction is the state of a light which is stored in a scene
+type LightAction struct {
+	On               *On                     `json:"on,omitempty"`
+	Dimming          *Dimming                `json:"dimming,omitempty"`
+	Color            *Color                  `json:"color,omitempty"`
+	ColorTemperature *ColorTemperatureUpdate `json:"color_temperature,omitempty"`
+	Effects          *Effects                `json:"effects,omitempty"`
+}
+
+// SceneParams is used to create or update a scene, nil fields are not changed.
+// Group can only be given on creation.
+type SceneParams struct {
+	Metadata *SceneMetadata      `json:"metadata,omitempty"`
+	Group    *ResourceIdentifier `json:"group,omitempty"`
+	Actions  []SceneAction       `json:"actions,omitempty"`
+	Speed    *float64            `json:"speed,omitempty"`
+	Recall   *SceneRecall        `json:"recall,omitempty"`
+}
+
+// SceneRecall applies the scene to its lights
+type SceneRecall struct {
+	Action   string   `json:"action,omitempty"`   // One of SceneRecall*
+	Duration int      `json:"duration,omitempty"` // Transition time in milliseconds
+	Dimming  *Dimming `json:"dimming,omitempty"`  // Brightness to recall the scene with
+}
@@ -0,0 +1,61 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var testSceneId = "d1e2f3a4-b5c6-4d7e-8f9a-0b1c2d3e4f5a"
+
+func TestSceneService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Scene_Get.json")
+	mux.HandleFunc(fmt.Sprintf("/resource/scene/%s", testSceneId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	got, _, err := client.Scenes.Get(ctx, testSceneId)
+	if err != nil {
+		t.Errorf("Scene.Get returned error: %+v", err)
+	}
+
+	assert.Equal(t, "Concentrate", got.Metadata.Name)
+	assert.Equal(t, ResourceIdentifier{RID: testRoomId, RType: ResourceTypeRoom}, got.Group)
+	assert.Len(t, got.Actions, 2)
+	assert.Equal(t, 233, got.Actions[0].Action.ColorTemperature.Mirek)
+	assert.False(t, got.Actions[1].Action.On.On)
+}
+
+func TestSceneService_Recall(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Scene_Update.json")
+	mux.HandleFunc(fmt.Sprintf("/resource/scene/%s", testSceneId), func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, map[string]interface{}{"recall": map[string]interface{}{"action": "active"}}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	ctx := context.Background()
+	err := client.Scenes.Recall(ctx, testSceneId)
+	if err != nil {
+		t.Errorf("Scene.Recall returned error: %+v", err)
+	}
+}
@@ -0,0 +1,220 @@
+package v2
+
+import (
+	"context"
+
+	hue "github.com/firstthumb/go-hue"
+)
+
+// MotionService has functions for motion sensors
+type MotionService service
+
+func (s *MotionService) motionServicePath(params ...string) string {
+	return s.client.path(ResourceTypeMotion, params...)
+}
+
+// GetAll returns all motion sensors
+func (s *MotionService) GetAll(ctx context.Context) ([]Motion, *Response, error) {
+	var motions []Motion
+	resp, err := s.client.getResources(ctx, s.motionServicePath(), &motions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return motions, resp, nil
+}
+
+// Get returns motion sensor by id
+func (s *MotionService) Get(ctx context.Context, id string) (*Motion, *Response, error) {
+	var motions []Motion
+	resp, err := s.client.getResources(ctx, s.motionServicePath(id), &motions)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(motions) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &motions[0], resp, nil
+}
+
+// Update changes whether the sensor is enabled
+func (s *MotionService) Update(ctx context.Context, id string, payload SensorUpdate) ([]ResourceIdentifier, *Response, error) {
+	return s.client.updateResource(ctx, s.motionServicePath(id), payload)
+}
+
+// TemperatureService has functions for temperature sensors
+type TemperatureService service
+
+func (s *TemperatureService) temperatureServicePath(params ...string) string {
+	return s.client.path(ResourceTypeTemperature, params...)
+}
+
+// GetAll returns all temperature sensors
+func (s *TemperatureService) GetAll(ctx context.Context) ([]Temperature, *Response, error) {
+	var temperatures []Temperature
+	resp, err := s.client.getResources(ctx, s.temperatureServicePath(), &temperatures)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return temperatures, resp, nil
+}
+
+// Get returns temperature sensor by id
+func (s *TemperatureService) Get(ctx context.Context, id string) (*Temperature, *Response, error) {
+	var temperatures []Temperature
+	resp, err := s.client.getResources(ctx, s.temperatureServicePath(id), &temperatures)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(temperatures) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &temperatures[0], resp, nil
+}
+
+// Update changes whether the sensor is enabled
+func (s *TemperatureService) Update(ctx context.Context, id string, payload SensorUpdate) ([]ResourceIdentifier, *Response, error) {
+	return s.client.updateResource(ctx, s.temperatureServicePath(id), payload)
+}
+
+// LightLevelService has functions for light level sensors
+type LightLevelService service
+
+func (s *LightLevelService) lightLevelServicePath(params ...string) string {
+	return s.client.path(ResourceTypeLightLevel, params...)
+}
+
+// GetAll returns all light level sensors
+func (s *LightLevelService) GetAll(ctx context.Context) ([]LightLevel, *Response, error) {
+	var lightLevels []LightLevel
+	resp, err := s.client.getResources(ctx, s.lightLevelServicePath(), &lightLevels)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return lightLevels, resp, nil
+}
+
+// Get returns light level sensor by id
+func (s *LightLevelService) Get(ctx context.Context, id string) (*LightLevel, *Response, error) {
+	var lightLevels []LightLevel
+	resp, err := s.client.getResources(ctx, s.lightLevelServicePath(id), &lightLevels)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(lightLevels) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &lightLevels[0], resp, nil
+}
+
+// Update changes whether the sensor is enabled
+func (s *LightLevelService) Update(ctx context.Context, id string, payload SensorUpdate) ([]ResourceIdentifier, *Response, error) {
+	return s.client.updateResource(ctx, s.lightLevelServicePath(id), payload)
+}
+
+// ButtonService has functions for buttons of switches
+type ButtonService service
+
+func (s *ButtonService) buttonServicePath(params ...string) string {
+	return s.client.path(ResourceTypeButton, params...)
+}
+
+// GetAll returns all buttons of switches
+func (s *ButtonService) GetAll(ctx context.Context) ([]Button, *Response, error) {
+	var buttons []Button
+	resp, err := s.client.getResources(ctx, s.buttonServicePath(), &buttons)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return buttons, resp, nil
+}
+
+// Get returns button by id
+func (s *ButtonService) Get(ctx context.Context, id string) (*Button, *Response, error) {
+	var buttons []Button
+	resp, err := s.client.getResources(ctx, s.buttonServicePath(id), &buttons)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(buttons) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &buttons[0], resp, nil
+}
+
+// DevicePowerService has functions for battery states of devices
+type DevicePowerService service
+
+func (s *DevicePowerService) devicePowerServicePath(params ...string) string {
+	return s.client.path(ResourceTypeDevicePower, params...)
+}
+
+// GetAll returns all battery states of devices
+func (s *DevicePowerService) GetAll(ctx context.Context) ([]DevicePower, *Response, error) {
+	var powers []DevicePower
+	resp, err := s.client.getResources(ctx, s.devicePowerServicePath(), &powers)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return powers, resp, nil
+}
+
+// Get returns battery state by id
+func (s *DevicePowerService) Get(ctx context.Context, id string) (*DevicePower, *Response, error) {
+	var powers []DevicePower
+	resp, err := s.client.getResources(ctx, s.devicePowerServicePath(id), &powers)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(powers) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &powers[0], resp, nil
+}
+
+// ZigbeeConnectivityService has functions for zigbee connectivity of devices
+type ZigbeeConnectivityService service
+
+func (s *ZigbeeConnectivityService) zigbeeConnectivityServicePath(params ...string) string {
+	return s.client.path(ResourceTypeZigbeeConnectivity, params...)
+}
+
+// GetAll returns all zigbee connectivity of devices
+func (s *ZigbeeConnectivityService) GetAll(ctx context.Context) ([]ZigbeeConnectivity, *Response, error) {
+	var connectivities []ZigbeeConnectivity
+	resp, err := s.client.getResources(ctx, s.zigbeeConnectivityServicePath(), &connectivities)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return connectivities, resp, nil
+}
+
+// Get returns zigbee connectivity by id
+func (s *ZigbeeConnectivityService) Get(ctx context.Context, id string) (*ZigbeeConnectivity, *Response, error) {
+	var connectivities []ZigbeeConnectivity
+	resp, err := s.client.getResources(ctx, s.zigbeeConnectivityServicePath(id), &connectivities)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	if len(connectivities) == 0 {
+		return nil, resp, hue.ErrInvalidResponse
+	}
+
+	return &connectivities[0], resp, nil
+}
@@ -0,0 +1,118 @@
+package v2
+
+// Button events
+const (
+	ButtonEventInitialPress       = "initial_press"
+	ButtonEventRepeat             = "repeat"
+	ButtonEventShortRelease       = "short_release"
+	ButtonEventLongRelease        = "long_release"
+	ButtonEventDoubleShortRelease = "double_short_release"
+	ButtonEventLongPress          = "long_press"
+)
+
+// Battery states
+const (
+	BatteryStateNormal   = "normal"
+	BatteryStateLow      = "low"
+	BatteryStateCritical = "critical"
+)
+
+// Zigbee connectivity statuses
+const (
+	ZigbeeStatusConnected              = "connected"
+	ZigbeeStatusDisconnected           = "disconnected"
+	ZigbeeStatusConnectivityIssue      = "connectivity_issue"
+	ZigbeeStatusUnidirectionalIncoming = "unidirectional_incoming"
+)
+
+// Motion struct that represents the motion sensor of a device
+type Motion struct {
+	ID      string             `json:"id"`
+	IDV1    string             `json:"id_v1,omitempty"` // Address of the sensor in the v1 API, e.g. /sensors/2
+	Type    string             `json:"type"`
+	Owner   ResourceIdentifier `json:"owner"`
+	Enabled bool               `json:"enabled"`
+	Motion  MotionReport       `json:"motion"`
+}
+
+type MotionReport struct {
+	Motion      bool `json:"motion"`
+	MotionValid bool `json:"motion_valid"`
+}
+
+// Temperature struct that represents the temperature sensor of a device
+type Temperature struct {
+	ID          string             `json:"id"`
+	IDV1        string             `json:"id_v1,omitempty"`
+	Type        string             `json:"type"`
+	Owner       ResourceIdentifier `json:"owner"`
+	Enabled     bool               `json:"enabled"`
+	Temperature TemperatureReport  `json:"temperature"`
+}
+
+type TemperatureReport struct {
+	Temperature      float64 `json:"temperature"` // Celsius
+	TemperatureValid bool    `json:"temperature_valid"`
+}
+
+// LightLevel struct that represents the light sensor of a device
+type LightLevel struct {
+	ID      string             `json:"id"`
+	IDV1    string             `json:"id_v1,omitempty"`
+	Type    string             `json:"type"`
+	Owner   ResourceIdentifier `json:"owner"`
+	Enabled bool               `json:"enabled"`
+	Light   LightLevelReport   `json:"light"`
+}
+
+type LightLevelReport struct {
+	LightLevel      int  `json:"light_level"` // 10000*log10(lux)+1
+	LightLevelValid bool `json:"light_level_valid"`
+}
+
+// SensorUpdate is used to enable or disable a motion, temperature or light level sensor
+type SensorUpdate struct {
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// Button struct that represents a button of a switch
+type Button struct {
+	ID       string             `json:"id"`
+	IDV1     string             `json:"id_v1,omitempty"`
+	Type     string             `json:"type"`
+	Owner    ResourceIdentifier `json:"owner"`
+	Metadata ButtonMetadata     `json:"metadata"`
+	Button   ButtonReport       `json:"button"`
+}
+
+type ButtonMetadata struct {
+	ControlID int `json:"control_id"` // Position of the button on the switch, starts at 1
+}
+
+type ButtonReport struct {
+	LastEvent string `json:"last_event,omitempty"` // One of ButtonEvent*
+}
+
+// DevicePower struct that represents the battery of a device
+type DevicePower struct {
+	ID         string             `json:"id"`
+	IDV1       string             `json:"id_v1,omitempty"`
+	Type       string             `json:"type"`
+	Owner      ResourceIdentifier `json:"owner"`
+	PowerState PowerState         `json:"power_state"`
+}
+
+type PowerState struct {
+	BatteryState string `json:"battery_state,omitempty"` // One of BatteryState*
+	BatteryLevel int    `json:"battery_level,omitempty"` // Percentage
+}
+
+// ZigbeeConnectivity struct that represents the zigbee connection of a device
+type ZigbeeConnectivity struct {
+	ID         string             `json:"id"`
+	IDV1       string             `json:"id_v1,omitempty"`
+	Type       string             `json:"type"`
+	Owner      ResourceIdentifier `json:"owner"`
+	Status     string             `json:"status"` // One of ZigbeeStatus*
+	MACAddress string             `json:"mac_address"`
+}
@@ -0,0 +1,139 @@
+package v2
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func handleFixture(t *testing.T, mux *http.ServeMux, path, file string) {
+	bytes, _ := ioutil.ReadFile(file)
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+}
+
+func TestMotionService_GetAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleFixture(t, mux, "/resource/motion", "testdata/Motion_GetAll.json")
+
+	ctx := context.Background()
+	got, _, err := client.Motions.GetAll(ctx)
+	if err != nil {
+		t.Errorf("Motion.GetAll returned error: %+v", err)
+	}
+
+	assert.Len(t, got, 1)
+	assert.True(t, got[0].Enabled)
+	assert.Equal(t, MotionReport{Motion: false, MotionValid: true}, got[0].Motion)
+}
+
+func TestMotionService_Update(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	bytes, _ := ioutil.ReadFile("testdata/Sensor_Update.json")
+	mux.HandleFunc("/resource/motion/6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+
+		var payload map[string]interface{}
+		getPayload(t, r, &payload)
+
+		assert.Equal(t, map[string]interface{}{"enabled": false}, payload)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, string(bytes))
+	})
+
+	enabled := false
+	ctx := context.Background()
+	_, _, err := client.Motions.Update(ctx, "6a7b8c9d-0e1f-4a2b-8c3d-4e5f6a7b8c9d", SensorUpdate{Enabled: &enabled})
+	if err != nil {
+		t.Errorf("Motion.Update returned error: %+v", err)
+	}
+}
+
+func TestTemperatureService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleFixture(t, mux, "/resource/temperature/7b8c9d0e-1f2a-4b3c-8d4e-5f6a7b8c9d0e", "testdata/Temperature_Get.json")
+
+	ctx := context.Background()
+	got, _, err := client.Temperatures.Get(ctx, "7b8c9d0e-1f2a-4b3c-8d4e-5f6a7b8c9d0e")
+	if err != nil {
+		t.Errorf("Temperature.Get returned error: %+v", err)
+	}
+
+	assert.Equal(t, 21.53, got.Temperature.Temperature)
+}
+
+func TestLightLevelService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleFixture(t, mux, "/resource/light_level/8c9d0e1f-2a3b-4c4d-8e5f-6a7b8c9d0e1f", "testdata/LightLevel_Get.json")
+
+	ctx := context.Background()
+	got, _, err := client.LightLevels.Get(ctx, "8c9d0e1f-2a3b-4c4d-8e5f-6a7b8c9d0e1f")
+	if err != nil {
+		t.Errorf("LightLevel.Get returned error: %+v", err)
+	}
+
+	assert.Equal(t, 14561, got.Light.LightLevel)
+}
+
+func TestButtonService_GetAll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleFixture(t, mux, "/resource/button", "testdata/Button_GetAll.json")
+
+	ctx := context.Background()
+	got, _, err := client.Buttons.GetAll(ctx)
+	if err != nil {
+		t.Errorf("Button.GetAll returned error: %+v", err)
+	}
+
+	assert.Equal(t, 1, got[0].Metadata.ControlID)
+	assert.Equal(t, ButtonEventShortRelease, got[0].Button.LastEvent)
+}
+
+func TestDevicePowerService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleFixture(t, mux, "/resource/device_power/0e1f2a3b-4c5d-4e6f-8a7b-8c9d0e1f2a3b", "testdata/DevicePower_Get.json")
+
+	ctx := context.Background()
+	got, _, err := client.DevicePowers.Get(ctx, "0e1f2a3b-4c5d-4e6f-8a7b-8c9d0e1f2a3b")
+	if err != nil {
+		t.Errorf("DevicePower.Get returned error: %+v", err)
+	}
+
+	assert.Equal(t, PowerState{BatteryState: BatteryStateNormal, BatteryLevel: 86}, got.PowerState)
+}
+
+func TestZigbeeConnectivityService_Get(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	handleFixture(t, mux, "/resource/zigbee_connectivity/c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f", "testdata/ZigbeeConnectivity_Get.json")
+
+	ctx := context.Background()
+	got, _, err := client.ZigbeeConnectivities.Get(ctx, "c1d2e3f4-a5b6-4c7d-8e9f-0a1b2c3d4e5f")
+	if err != nil {
+		t.Errorf("ZigbeeConnectivity.Get returned error: %+v", err)
+	}
+
+	assert.Equal(t, ZigbeeStatusConnected, got.Status)
+	assert.Equal(t, ResourceIdentifier{RID: testDeviceId, RType: ResourceTypeDevice}, got.Owner)
+}
@@ -0,0 +1,281 @@
+package hue
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"strconv"
+	"time"
+
+	funk "github.com/thoas/go-funk"
+)
+
+const (
+	defaultWatchInterval   = time.Second
+	defaultWatchMaxBackoff = time.Minute
+)
+
+// attributes of a resource by name, values are compared with reflect.DeepEqual
+type attributes map[string]interface{}
+
+// Watcher polls lights, groups and sensors and reports what changed between two polls.
+// It is meant for bridges which can't stream events, e.g. through the remote API.
+type Watcher struct {
+	client *Client
+	opts   WatcherOptions
+
+	snapshots map[ResourceType]map[string]attributes
+}
+
+// NewWatcher returns a watcher of the client, opts may be nil
+func NewWatcher(client *Client, opts *WatcherOptions) *Watcher {
+	w := &Watcher{client: client, snapshots: make(map[ResourceType]map[string]attributes)}
+	if opts != nil {
+		w.opts = *opts
+	}
+	if w.opts.Interval <= 0 {
+		w.opts.Interval = defaultWatchInterval
+	}
+	if w.opts.MaxBackoff < w.opts.Interval {
+		w.opts.MaxBackoff = defaultWatchMaxBackoff
+	}
+	if w.opts.Lights == nil && w.opts.Groups == nil && w.opts.Sensors == nil {
+		w.opts.Lights, w.opts.Groups, w.opts.Sensors = &WatchFilter{}, &WatchFilter{}, &WatchFilter{}
+	}
+	return w
+}
+
+// Watch polls the bridge and calls handler for every change until ctx is done, it returns ctx.Err().
+// The first poll is the baseline, it doesn't report any change.
+func (w *Watcher) Watch(ctx context.Context, handler func(ChangeEvent)) error {
+	wait := w.opts.Interval
+	for {
+		events, err := w.poll(ctx)
+		for _, e := range events {
+			handler(e)
+		}
+
+		if err != nil && ctx.Err() == nil {
+			if w.opts.OnError != nil {
+				w.opts.OnError(err)
+			}
+			wait *= 2
+			if wait > w.opts.MaxBackoff {
+				wait = w.opts.MaxBackoff
+			}
+		} else {
+			wait = w.opts.Interval
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(wait):
+		}
+	}
+}
+
+// Changes delivers the changes over a channel, it is closed when ctx is done
+func (w *Watcher) Changes(ctx context.Context) <-chan ChangeEvent {
+	changes := make(chan ChangeEvent)
+
+	go func() {
+		defer close(changes)
+
+		w.Watch(ctx, func(e ChangeEvent) {
+			select {
+			case changes <- e:
+			case <-ctx.Done():
+			}
+		})
+	}()
+
+	return changes
+}
+
+// poll fetches the watched resources and returns their changes, resources which failed keep their snapshot
+func (w *Watcher) poll(ctx context.Context) ([]ChangeEvent, error) {
+	var events []ChangeEvent
+	var firstErr error
+
+	if w.opts.Lights != nil {
+		lights, _, err := w.client.Lights.GetAll(ctx)
+		if err == nil {
+			snapshot := make(map[string]attributes)
+			for _, l := range lights {
+				snapshot[strconv.Itoa(l.ID)] = lightAttributes(l)
+			}
+			events = append(events, w.update(ResourceTypeLight, snapshot, w.opts.Lights)...)
+		} else if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if w.opts.Groups != nil {
+		groups, _, err := w.client.Groups.GetAll(ctx)
+		if err == nil {
+			snapshot := make(map[string]attributes)
+			for _, g := range groups {
+				snapshot[strconv.Itoa(g.ID)] = groupAttributes(g)
+			}
+			events = append(events, w.update(ResourceTypeGroup, snapshot, w.opts.Groups)...)
+		} else if firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	if w.opts.Sensors != nil {
+		sensors, _, err := w.client.Sensors.GetAll(ctx)
+		switch {
+		case err == nil:
+			snapshot := make(map[string]attributes)
+			for _, s := range sensors {
+				snapshot[strconv.Itoa(s.ID)] = sensorAttributes(s)
+			}
+			events = append(events, w.update(ResourceTypeSensor, snapshot, w.opts.Sensors)...)
+		case errors.Is(err, ErrResourceNotAvailable), errors.Is(err, ErrMethodNotAvailable):
+			// The bridge doesn't expose sensors, stop asking
+			w.opts.Sensors = nil
+		case firstErr == nil:
+			firstErr = err
+		}
+	}
+
+	return events, firstErr
+}
+
+// update stores the snapshot and returns its changes from the previous one
+func (w *Watcher) update(t ResourceType, snapshot map[string]attributes, filter *WatchFilter) []ChangeEvent {
+	previous, ok := w.snapshots[t]
+	w.snapshots[t] = snapshot
+	if !ok {
+		return nil
+	}
+
+	var events []ChangeEvent
+	for _, id := range sortedKeys(snapshot, previous) {
+		if !filter.matchID(id) {
+			continue
+		}
+
+		old, existed := previous[id]
+		current, exists := snapshot[id]
+		switch {
+		case !existed:
+			events = append(events, ChangeEvent{Type: t, ID: id, Kind: ChangeAdded})
+		case !exists:
+			events = append(events, ChangeEvent{Type: t, ID: id, Kind: ChangeRemoved})
+		default:
+			for _, e := range diff(t, id, old, current) {
+				if filter.matchAttribute(e.Attribute) {
+					events = append(events, e)
+				}
+			}
+		}
+	}
+	return events
+}
+
+// diff returns the attributes which changed.
+// A sensor reports the same value again when e.g. a button is pressed twice, it is noticed by lastupdated.
+func diff(t ResourceType, id string, old, current attributes) []ChangeEvent {
+	lastUpdated, _ := current[lastUpdatedAttribute].(string)
+	updated := lastUpdated != "" && old[lastUpdatedAttribute] != current[lastUpdatedAttribute]
+
+	var events []ChangeEvent
+	var state []ChangeEvent
+	for _, name := range sortedKeys(current, old) {
+		if name == lastUpdatedAttribute {
+			continue
+		}
+		e := ChangeEvent{Type: t, ID: id, Kind: ChangeUpdated, Attribute: name, Old: old[name], New: current[name]}
+		if updated && funk.ContainsString(sensorStateAttributes, name) {
+			e.LastUpdated = lastUpdated
+			state = append(state, e)
+		}
+		if !reflect.DeepEqual(old[name], current[name]) {
+			events = append(events, e)
+		}
+	}
+
+	if updated && len(events) == 0 {
+		return state
+	}
+	return events
+}
+
+const lastUpdatedAttribute = "lastupdated"
+
+// sensorStateAttributes are the attributes of SensorState which change with lastupdated
+var sensorStateAttributes = []string{"presence", "lightlevel", "dark", "daylight", "temperature", "humidity", "buttonevent", "open", "flag", "status"}
+
+func sortedKeys(maps ...interface{}) []string {
+	set := make(map[string]bool)
+	for _, m := range maps {
+		for _, k := range reflect.ValueOf(m).MapKeys() {
+			set[k.String()] = true
+		}
+	}
+
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func lightAttributes(l Light) attributes {
+	return attributes{
+		"name":      l.Name,
+		"on":        l.State.On,
+		"bri":       l.State.Bri,
+		"hue":       l.State.Hue,
+		"sat":       l.State.Sat,
+		"ct":        l.State.CT,
+		"xy":        l.State.XY,
+		"effect":    l.State.Effect,
+		"alert":     l.State.Alert,
+		"colormode": l.State.ColorMode,
+		"reachable": l.State.Reachable,
+	}
+}
+
+func groupAttributes(g Group) attributes {
+	return attributes{
+		"name":   g.Name,
+		"lights": g.Lights,
+		"all_on": g.State.AllOn,
+		"any_on": g.State.AnyOn,
+	}
+}
+
+func sensorAttributes(s Sensor) attributes {
+	a := attributes{
+		"name":               s.Name,
+		lastUpdatedAttribute: s.State.LastUpdated,
+	}
+
+	for name, v := range map[string]interface{}{
+		"presence":    s.State.Presence,
+		"lightlevel":  s.State.LightLevel,
+		"dark":        s.State.Dark,
+		"daylight":    s.State.Daylight,
+		"temperature": s.State.Temperature,
+		"humidity":    s.State.Humidity,
+		"buttonevent": s.State.ButtonEvent,
+		"open":        s.State.Open,
+		"flag":        s.State.Flag,
+		"status":      s.State.Status,
+		"on":          s.Config.On,
+		"reachable":   s.Config.Reachable,
+		"battery":     s.Config.Battery,
+	} {
+		// Only the attributes of the sensor type are set
+		if rv := reflect.ValueOf(v); !rv.IsNil() {
+			a[name] = rv.Elem().Interface()
+		}
+	}
+	return a
+}
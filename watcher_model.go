@@ -0,0 +1,69 @@
+package hue
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	funk "github.com/thoas/go-funk"
+)
+
+// Kinds of change
+const (
+	ChangeAdded   = "added"
+	ChangeRemoved = "removed"
+	ChangeUpdated = "updated"
+)
+
+// ChangeEvent is a change of a light, group or sensor which the Watcher noticed between two polls
+type ChangeEvent struct {
+	Type        ResourceType // ResourceTypeLight, ResourceTypeGroup or ResourceTypeSensor
+	ID          string       // Id of the resource
+	Kind        string       // ChangeAdded, ChangeRemoved or ChangeUpdated
+	Attribute   string       // Changed attribute e.g. on, bri or buttonevent, empty if the resource was added or removed
+	Old         interface{}  // Previous value of the attribute
+	New         interface{}  // Current value of the attribute
+	LastUpdated string       // Time of the change reported by sensors
+}
+
+// String describes the change, e.g. "light 3 turned off" or "light 3 bri changed 120→200"
+func (e ChangeEvent) String() string {
+	resource := fmt.Sprintf("%v %v", strings.TrimSuffix(string(e.Type), "s"), e.ID)
+	switch {
+	case e.Kind != ChangeUpdated:
+		return fmt.Sprintf("%v %v", resource, e.Kind)
+	case e.LastUpdated != "":
+		return fmt.Sprintf("%v %v %v at %v", resource, e.Attribute, e.New, e.LastUpdated)
+	case e.Attribute == "on":
+		if e.New == true {
+			return resource + " turned on"
+		}
+		return resource + " turned off"
+	}
+	return fmt.Sprintf("%v %v changed %v→%v", resource, e.Attribute, e.Old, e.New)
+}
+
+// WatchFilter selects which changes of a resource type are reported
+type WatchFilter struct {
+	IDs        []string // Only changes of these ids, all if empty
+	Attributes []string // Only changes of these attributes, all if empty. Additions and removals are always reported
+}
+
+// WatcherOptions configures a Watcher, resource types with a nil filter aren't polled.
+// If every filter is nil, lights, groups and sensors are watched.
+type WatcherOptions struct {
+	Interval   time.Duration // Time between polls, 1 second by default
+	MaxBackoff time.Duration // The interval doubles after every failed poll up to MaxBackoff, 1 minute by default
+	Lights     *WatchFilter
+	Groups     *WatchFilter
+	Sensors    *WatchFilter
+	OnError    func(error) // Called when a poll fails, the watcher keeps polling
+}
+
+func (f *WatchFilter) matchID(id string) bool {
+	return len(f.IDs) == 0 || funk.ContainsString(f.IDs, id)
+}
+
+func (f *WatchFilter) matchAttribute(attribute string) bool {
+	return len(f.Attributes) == 0 || funk.ContainsString(f.Attributes, attribute)
+}
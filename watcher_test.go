@@ -0,0 +1,192 @@
+package hue
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// bridgeState is served by handleBridgeState, tests change it between polls
+type bridgeState struct {
+	sync.Mutex
+	lights  map[string]Light
+	groups  map[string]Group
+	sensors map[string]Sensor
+}
+
+func handleBridgeState(t *testing.T, mux *http.ServeMux, state *bridgeState) {
+	serve := func(v func() interface{}) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			testMethod(t, r, "GET")
+			state.Lock()
+			defer state.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(v())
+		}
+	}
+	mux.HandleFunc("/username/lights", serve(func() interface{} { return state.lights }))
+	mux.HandleFunc("/username/groups", serve(func() interface{} { return state.groups }))
+	mux.HandleFunc("/username/sensors", serve(func() interface{} { return state.sensors }))
+}
+
+func TestWatcher_Poll(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	state := &bridgeState{
+		lights: map[string]Light{
+			"1": {Name: "Desk", State: State{On: true, Bri: 120}},
+			"3": {Name: "Hall", State: State{On: true, Bri: 254}},
+		},
+		groups: map[string]Group{
+			"1": {Name: "Office", Lights: []string{"1"}, State: GroupState{AllOn: true, AnyOn: true}},
+		},
+		sensors: map[string]Sensor{
+			"2": {Name: "Dimmer", Type: SensorTypeZLLSwitch, State: SensorState{ButtonEvent: Int(1002), LastUpdated: "2020-11-03T10:00:00"}, Config: SensorConfig{On: Bool(true), Battery: Int(80)}},
+		},
+	}
+	handleBridgeState(t, mux, state)
+
+	ctx := context.Background()
+	w := NewWatcher(client, nil)
+
+	events, err := w.poll(ctx)
+	assert.NoError(t, err)
+	assert.Empty(t, events, "first poll is the baseline")
+
+	state.lights["1"] = Light{Name: "Desk", State: State{On: true, Bri: 200}}
+	state.lights["3"] = Light{Name: "Hall", State: State{On: false, Bri: 254}}
+	state.lights["4"] = Light{Name: "Kitchen"}
+	state.groups["1"] = Group{Name: "Office", Lights: []string{"1"}, State: GroupState{AllOn: false, AnyOn: true}}
+	// The same button is pressed again
+	state.sensors["2"] = Sensor{Name: "Dimmer", Type: SensorTypeZLLSwitch, State: SensorState{ButtonEvent: Int(1002), LastUpdated: "2020-11-03T10:05:00"}, Config: SensorConfig{On: Bool(true), Battery: Int(80)}}
+
+	events, err = w.poll(ctx)
+	assert.NoError(t, err)
+
+	var got []string
+	for _, e := range events {
+		got = append(got, e.String())
+	}
+	assert.Equal(t, []string{
+		"light 1 bri changed 120→200",
+		"light 3 turned off",
+		"light 4 added",
+		"group 1 all_on changed true→false",
+		"sensor 2 buttonevent 1002 at 2020-11-03T10:05:00",
+	}, got)
+
+	assert.Equal(t, ChangeEvent{Type: ResourceTypeLight, ID: "1", Kind: ChangeUpdated, Attribute: "bri", Old: uint8(120), New: uint8(200)}, events[0])
+
+	delete(state.lights, "4")
+	events, err = w.poll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []ChangeEvent{{Type: ResourceTypeLight, ID: "4", Kind: ChangeRemoved}}, events)
+}
+
+func TestWatcher_Filter(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	state := &bridgeState{
+		lights: map[string]Light{
+			"1": {Name: "Desk", State: State{On: true, Bri: 120}},
+			"3": {Name: "Hall", State: State{On: true, Bri: 254}},
+		},
+	}
+	handleBridgeState(t, mux, state)
+
+	ctx := context.Background()
+	w := NewWatcher(client, &WatcherOptions{Lights: &WatchFilter{IDs: []string{"1"}, Attributes: []string{"on"}}})
+
+	_, err := w.poll(ctx)
+	assert.NoError(t, err)
+
+	state.lights["1"] = Light{Name: "Desk", State: State{On: false, Bri: 200}}
+	state.lights["3"] = Light{Name: "Hall", State: State{On: false, Bri: 254}}
+
+	events, err := w.poll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, []ChangeEvent{{Type: ResourceTypeLight, ID: "1", Kind: ChangeUpdated, Attribute: "on", Old: true, New: false}}, events)
+}
+
+func TestWatcher_SensorsNotAvailable(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	mux.HandleFunc("/username/sensors", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[{"error":{"type":3,"address":"/sensors","description":"resource, /sensors, not available"}}]`))
+	})
+
+	ctx := context.Background()
+	w := NewWatcher(client, &WatcherOptions{Sensors: &WatchFilter{}})
+
+	_, err := w.poll(ctx)
+	assert.NoError(t, err)
+	assert.Nil(t, w.opts.Sensors)
+}
+
+func TestWatcher_Watch(t *testing.T) {
+	client, mux, _, teardown := setup()
+	defer teardown()
+
+	var mu sync.Mutex
+	polls := 0
+	mux.HandleFunc("/username/lights", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+		w.Header().Set("Content-Type", "application/json")
+		switch polls {
+		case 1:
+			w.Write([]byte(`{"1": {"name": "Desk", "state": {"on": true}}}`))
+		case 2:
+			w.Write([]byte(`[{"error":{"type":901,"address":"/lights","description":"internal error, 404"}}]`))
+		default:
+			w.Write([]byte(`{"1": {"name": "Desk", "state": {"on": false}}}`))
+		}
+	})
+
+	var errs []error
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	w := NewWatcher(client, &WatcherOptions{
+		Interval: time.Millisecond,
+		Lights:   &WatchFilter{},
+		OnError:  func(err error) { errs = append(errs, err) },
+	})
+
+	var got []ChangeEvent
+	err := w.Watch(ctx, func(e ChangeEvent) {
+		got = append(got, e)
+		cancel()
+	})
+
+	assert.Equal(t, context.Canceled, err)
+	assert.Len(t, errs, 1)
+	assert.True(t, errors.Is(errs[0], ErrInternalError))
+	assert.Equal(t, []ChangeEvent{{Type: ResourceTypeLight, ID: "1", Kind: ChangeUpdated, Attribute: "on", Old: true, New: false}}, got)
+}
+
+func TestChangeEvent_String(t *testing.T) {
+	tests := []struct {
+		event ChangeEvent
+		want  string
+	}{
+		{ChangeEvent{Type: ResourceTypeLight, ID: "3", Kind: ChangeUpdated, Attribute: "on", Old: false, New: true}, "light 3 turned on"},
+		{ChangeEvent{Type: ResourceTypeGroup, ID: "1", Kind: ChangeRemoved}, "group 1 removed"},
+		{ChangeEvent{Type: ResourceTypeSensor, ID: "7", Kind: ChangeUpdated, Attribute: "battery", Old: 80, New: 79}, "sensor 7 battery changed 80→79"},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, tt.event.String())
+	}
+}